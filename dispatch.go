@@ -0,0 +1,614 @@
+package hyprland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dispatcher is a typed representation of a Hyprland dispatcher, e.g.:
+// 'focuswindow address:0x1234'.
+// Use the constructors in this package (e.g.: [FocusWindow], [Exec]) to
+// create them and [RequestClient.DispatchAll] to send them, or
+// [Dispatcher.String] to get the params expected by [RequestClient.Dispatch].
+// See https://wiki.hyprland.org/Configuring/Dispatchers/ for the full list.
+type Dispatcher struct {
+	name string
+	args string
+	err  error
+}
+
+// Direction used by dispatchers that moves focus or windows around.
+type Direction string
+
+const (
+	DirectionLeft  Direction = "l"
+	DirectionRight Direction = "r"
+	DirectionUp    Direction = "u"
+	DirectionDown  Direction = "d"
+)
+
+// Switch used by dispatchers that accepts 'on', 'off' or 'toggle'.
+type Switch string
+
+const (
+	SwitchOn     Switch = "on"
+	SwitchOff    Switch = "off"
+	SwitchToggle Switch = "toggle"
+)
+
+// GroupLock used by dispatchers that (un)lock groups.
+type GroupLock string
+
+const (
+	GroupLockLock   GroupLock = "lock"
+	GroupLockUnlock GroupLock = "unlock"
+	GroupLockToggle GroupLock = "toggle"
+)
+
+// NewDispatcher creates a custom dispatcher, useful for dispatchers that
+// have no constructor in this package (e.g.: the ones from plugins).
+// The args are passed as-is, so they should already be in the format
+// expected by Hyprland.
+func NewDispatcher(name string, args string) Dispatcher {
+	return Dispatcher{name: name, args: args}
+}
+
+// Name of the dispatcher, e.g.: 'focuswindow'.
+func (d Dispatcher) Name() string {
+	return d.name
+}
+
+// Args of the dispatcher, e.g.: 'address:0x1234'.
+func (d Dispatcher) Args() string {
+	return d.args
+}
+
+// Validate the dispatcher arguments, e.g.: the selectors passed to its
+// constructor. Returns an error wrapping [ErrInvalidSelector] if any of them
+// is invalid.
+func (d Dispatcher) Validate() error {
+	return d.err
+}
+
+// String returns the dispatcher in the format expected by
+// [RequestClient.Dispatch], e.g.: 'focuswindow address:0x1234'.
+func (d Dispatcher) String() string {
+	if d.args == "" {
+		return d.name
+	}
+
+	return d.name + " " + d.args
+}
+
+// Exec dispatcher, executes a shell command with optional window rules,
+// e.g.: Exec("kitty", "workspace 2 silent", "float").
+func Exec(cmd string, rules ...string) Dispatcher {
+	if len(rules) > 0 {
+		return NewDispatcher("exec", "["+strings.Join(rules, ";")+"] "+cmd)
+	}
+
+	return NewDispatcher("exec", cmd)
+}
+
+// ExecR dispatcher, executes a raw shell command (does not support rules).
+func ExecR(cmd string) Dispatcher {
+	return NewDispatcher("execr", cmd)
+}
+
+// Pass dispatcher, passes the key (with mods) to a specified window.
+func Pass(w WindowSelector) Dispatcher {
+	return NewDispatcher("pass", string(w)).validate(w)
+}
+
+// SendShortcut dispatcher, sends specified keys (with mods) to an optionally
+// specified window, e.g.: SendShortcut("SUPER", "F4", "").
+func SendShortcut(mods, key string, w WindowSelector) Dispatcher {
+	return NewDispatcher("sendshortcut", joinArgs(", ", mods, key, string(w))).validate(optional[WindowSelector]{w})
+}
+
+// KillActive dispatcher, closes (not kills) the active window.
+func KillActive() Dispatcher {
+	return NewDispatcher("killactive", "")
+}
+
+// ForceKillActive dispatcher, kills the active window.
+func ForceKillActive() Dispatcher {
+	return NewDispatcher("forcekillactive", "")
+}
+
+// CloseWindow dispatcher, closes a specified window.
+func CloseWindow(w WindowSelector) Dispatcher {
+	return NewDispatcher("closewindow", string(w)).validate(w)
+}
+
+// KillWindow dispatcher, kills a specified window.
+func KillWindow(w WindowSelector) Dispatcher {
+	return NewDispatcher("killwindow", string(w)).validate(w)
+}
+
+// Signal dispatcher, sends a signal to the active window.
+func Signal(sig int) Dispatcher {
+	return NewDispatcher("signal", strconv.Itoa(sig))
+}
+
+// SignalWindow dispatcher, sends a signal to a specified window.
+func SignalWindow(w WindowSelector, sig int) Dispatcher {
+	return NewDispatcher("signalwindow", joinArgs(",", string(w), strconv.Itoa(sig))).validate(w)
+}
+
+// FocusWorkspace dispatcher, changes the workspace ('workspace' dispatcher).
+func FocusWorkspace(ws WorkspaceSelector) Dispatcher {
+	return NewDispatcher("workspace", string(ws)).validate(ws)
+}
+
+// MoveToWorkspace dispatcher, moves the active window to a workspace.
+// If silent is true, the focus will stay in the current workspace.
+func MoveToWorkspace(ws WorkspaceSelector, silent bool) Dispatcher {
+	return MoveWindowToWorkspace(ws, "", silent)
+}
+
+// MoveWindowToWorkspace dispatcher, moves a specified window to a workspace.
+// If silent is true, the focus will stay in the current workspace.
+func MoveWindowToWorkspace(ws WorkspaceSelector, w WindowSelector, silent bool) Dispatcher {
+	name := "movetoworkspace"
+	if silent {
+		name = "movetoworkspacesilent"
+	}
+
+	return NewDispatcher(name, joinArgs(",", string(ws), string(w))).validate(ws, optional[WindowSelector]{w})
+}
+
+// ToggleFloating dispatcher, toggles the floating state of a window.
+func ToggleFloating(w WindowSelector) Dispatcher {
+	return NewDispatcher("togglefloating", string(w)).validate(optional[WindowSelector]{w})
+}
+
+// SetFloating dispatcher, sets a window to floating.
+func SetFloating(w WindowSelector) Dispatcher {
+	return NewDispatcher("setfloating", string(w)).validate(optional[WindowSelector]{w})
+}
+
+// SetTiled dispatcher, sets a window to tiled.
+func SetTiled(w WindowSelector) Dispatcher {
+	return NewDispatcher("settiled", string(w)).validate(optional[WindowSelector]{w})
+}
+
+// ToggleFullscreen dispatcher, toggles the fullscreen state of the active
+// window. If maximize is true, it will maximize the window instead.
+func ToggleFullscreen(maximize bool) Dispatcher {
+	return NewDispatcher("fullscreen", boolArg(maximize))
+}
+
+// SetFullscreenState dispatcher, sets the internal (Hyprland) and client
+// (application) fullscreen states of the active window.
+func SetFullscreenState(internal, client FullscreenState) Dispatcher {
+	return NewDispatcher(
+		"fullscreenstate",
+		joinArgs(" ", strconv.Itoa(int(internal)), strconv.Itoa(int(client))),
+	)
+}
+
+// DPMS dispatcher, sets all (or a specified) monitors DPMS status.
+func DPMS(s Switch, m MonitorSelector) Dispatcher {
+	return NewDispatcher("dpms", joinArgs(" ", string(s), string(m))).validate(optional[MonitorSelector]{m})
+}
+
+// Pin dispatcher, pins a window (i.e.: shows it on all workspaces). Only
+// works for floating windows.
+func Pin(w WindowSelector) Dispatcher {
+	return NewDispatcher("pin", string(w)).validate(optional[WindowSelector]{w})
+}
+
+// MoveFocus dispatcher, moves the focus in a direction.
+func MoveFocus(d Direction) Dispatcher {
+	return NewDispatcher("movefocus", string(d))
+}
+
+// MoveWindow dispatcher, moves the active window in a direction.
+func MoveWindow(d Direction) Dispatcher {
+	return NewDispatcher("movewindow", string(d))
+}
+
+// MoveWindowToMonitor dispatcher, moves the active window to a monitor.
+// If silent is true, the focus will stay in the current monitor.
+func MoveWindowToMonitor(m MonitorSelector, silent bool) Dispatcher {
+	args := "mon:" + string(m)
+	if silent {
+		args += " silent"
+	}
+
+	return NewDispatcher("movewindow", args).validate(m)
+}
+
+// SwapWindow dispatcher, swaps the active window with another window in a
+// direction.
+func SwapWindow(d Direction) Dispatcher {
+	return NewDispatcher("swapwindow", string(d))
+}
+
+// CenterWindow dispatcher, centers the active window (floating only). If
+// respectReserved is true, it will respect the monitor reserved area.
+func CenterWindow(respectReserved bool) Dispatcher {
+	if respectReserved {
+		return NewDispatcher("centerwindow", "1")
+	}
+
+	return NewDispatcher("centerwindow", "")
+}
+
+// ResizeActive dispatcher, resizes the active window by a relative amount of
+// pixels.
+func ResizeActive(dx, dy int) Dispatcher {
+	return NewDispatcher("resizeactive", joinArgs(" ", strconv.Itoa(dx), strconv.Itoa(dy)))
+}
+
+// ResizeActiveExact dispatcher, resizes the active window to an exact size
+// in pixels.
+func ResizeActiveExact(w, h int) Dispatcher {
+	return NewDispatcher("resizeactive", joinArgs(" ", "exact", strconv.Itoa(w), strconv.Itoa(h)))
+}
+
+// MoveActive dispatcher, moves the active window by a relative amount of
+// pixels.
+func MoveActive(dx, dy int) Dispatcher {
+	return NewDispatcher("moveactive", joinArgs(" ", strconv.Itoa(dx), strconv.Itoa(dy)))
+}
+
+// MoveActiveExact dispatcher, moves the active window to an exact position
+// in pixels.
+func MoveActiveExact(x, y int) Dispatcher {
+	return NewDispatcher("moveactive", joinArgs(" ", "exact", strconv.Itoa(x), strconv.Itoa(y)))
+}
+
+// ResizeWindowPixel dispatcher, resizes a specified window by a relative
+// amount of pixels.
+func ResizeWindowPixel(dx, dy int, w WindowSelector) Dispatcher {
+	return NewDispatcher(
+		"resizewindowpixel",
+		joinArgs(",", strconv.Itoa(dx)+" "+strconv.Itoa(dy), string(w)),
+	).validate(w)
+}
+
+// MoveWindowPixel dispatcher, moves a specified window by a relative amount
+// of pixels.
+func MoveWindowPixel(dx, dy int, w WindowSelector) Dispatcher {
+	return NewDispatcher(
+		"movewindowpixel",
+		joinArgs(",", strconv.Itoa(dx)+" "+strconv.Itoa(dy), string(w)),
+	).validate(w)
+}
+
+// CycleNext dispatcher, focuses the next (or previous) window in the
+// workspace.
+func CycleNext(prev bool) Dispatcher {
+	if prev {
+		return NewDispatcher("cyclenext", "prev")
+	}
+
+	return NewDispatcher("cyclenext", "")
+}
+
+// SwapNext dispatcher, swaps the focused window with the next (or previous)
+// window in the workspace.
+func SwapNext(prev bool) Dispatcher {
+	if prev {
+		return NewDispatcher("swapnext", "prev")
+	}
+
+	return NewDispatcher("swapnext", "")
+}
+
+// TagWindow dispatcher, applies a tag to a specified window (or the active
+// one). Use the '+tag' or '-tag' syntax to set or unset instead of toggling.
+func TagWindow(tag string, w WindowSelector) Dispatcher {
+	return NewDispatcher("tagwindow", joinArgs(" ", tag, string(w))).validate(optional[WindowSelector]{w})
+}
+
+// FocusWindow dispatcher, focuses the first window matching the selector.
+func FocusWindow(w WindowSelector) Dispatcher {
+	return NewDispatcher("focuswindow", string(w)).validate(w)
+}
+
+// FocusMonitor dispatcher, focuses a monitor.
+func FocusMonitor(m MonitorSelector) Dispatcher {
+	return NewDispatcher("focusmonitor", string(m)).validate(m)
+}
+
+// SplitRatio dispatcher, changes the split ratio by a relative amount.
+func SplitRatio(delta float64) Dispatcher {
+	return NewDispatcher("splitratio", formatFloat(delta))
+}
+
+// SplitRatioExact dispatcher, sets the split ratio to an exact value.
+func SplitRatioExact(ratio float64) Dispatcher {
+	return NewDispatcher("splitratio", joinArgs(" ", "exact", formatFloat(ratio)))
+}
+
+// MoveCursorToCorner dispatcher, moves the cursor to the corner of the
+// active window. Corner is 0 (bottom left), 1 (bottom right), 2 (top right)
+// or 3 (top left).
+func MoveCursorToCorner(corner int) Dispatcher {
+	return NewDispatcher("movecursortocorner", strconv.Itoa(corner))
+}
+
+// MoveCursor dispatcher, moves the cursor to a specified position.
+func MoveCursor(x, y int) Dispatcher {
+	return NewDispatcher("movecursor", joinArgs(" ", strconv.Itoa(x), strconv.Itoa(y)))
+}
+
+// RenameWorkspace dispatcher, renames a workspace.
+func RenameWorkspace(id int, name string) Dispatcher {
+	return NewDispatcher("renameworkspace", joinArgs(" ", strconv.Itoa(id), name))
+}
+
+// Exit dispatcher, exits Hyprland (and the current session!).
+func Exit() Dispatcher {
+	return NewDispatcher("exit", "")
+}
+
+// ForceRendererReload dispatcher, forces the renderer to reload all
+// resources and outputs.
+func ForceRendererReload() Dispatcher {
+	return NewDispatcher("forcerendererreload", "")
+}
+
+// MoveCurrentWorkspaceToMonitor dispatcher, moves the active workspace to a
+// monitor.
+func MoveCurrentWorkspaceToMonitor(m MonitorSelector) Dispatcher {
+	return NewDispatcher("movecurrentworkspacetomonitor", string(m)).validate(m)
+}
+
+// FocusWorkspaceOnCurrentMonitor dispatcher, focuses the workspace on the
+// current monitor, moving it if necessary.
+func FocusWorkspaceOnCurrentMonitor(ws WorkspaceSelector) Dispatcher {
+	return NewDispatcher("focusworkspaceoncurrentmonitor", string(ws)).validate(ws)
+}
+
+// MoveWorkspaceToMonitor dispatcher, moves a workspace to a monitor.
+func MoveWorkspaceToMonitor(ws WorkspaceSelector, m MonitorSelector) Dispatcher {
+	return NewDispatcher("moveworkspacetomonitor", joinArgs(" ", string(ws), string(m))).validate(ws, m)
+}
+
+// SwapActiveWorkspaces dispatcher, swaps the active workspaces between two
+// monitors.
+func SwapActiveWorkspaces(m1, m2 MonitorSelector) Dispatcher {
+	return NewDispatcher("swapactiveworkspaces", joinArgs(" ", string(m1), string(m2))).validate(m1, m2)
+}
+
+// BringActiveToTop dispatcher, brings the active window to the top of the
+// stack.
+func BringActiveToTop() Dispatcher {
+	return NewDispatcher("bringactivetotop", "")
+}
+
+// AlterZOrder dispatcher, modifies the window stack order of a specified
+// window (or the active one) to either top or bottom.
+func AlterZOrder(top bool, w WindowSelector) Dispatcher {
+	zheight := "bottom"
+	if top {
+		zheight = "top"
+	}
+
+	return NewDispatcher("alterzorder", joinArgs(",", zheight, string(w))).validate(optional[WindowSelector]{w})
+}
+
+// ToggleSpecialWorkspace dispatcher, toggles a special workspace on/off. An
+// empty name means the default special workspace.
+func ToggleSpecialWorkspace(name string) Dispatcher {
+	return NewDispatcher("togglespecialworkspace", name)
+}
+
+// FocusUrgentOrLast dispatcher, focuses the urgent window or the last window.
+func FocusUrgentOrLast() Dispatcher {
+	return NewDispatcher("focusurgentorlast", "")
+}
+
+// FocusCurrentOrLast dispatcher, switch focus from current to previously
+// focused window.
+func FocusCurrentOrLast() Dispatcher {
+	return NewDispatcher("focuscurrentorlast", "")
+}
+
+// ToggleGroup dispatcher, toggles the current active window into a group.
+func ToggleGroup() Dispatcher {
+	return NewDispatcher("togglegroup", "")
+}
+
+// ChangeGroupActive dispatcher, switches to the next (forward) or previous
+// window in a group.
+func ChangeGroupActive(forward bool) Dispatcher {
+	return NewDispatcher("changegroupactive", forwardArg(forward))
+}
+
+// ChangeGroupActiveIndex dispatcher, switches to the window in a group with
+// the specified index (starting at 1).
+func ChangeGroupActiveIndex(index int) Dispatcher {
+	return NewDispatcher("changegroupactive", strconv.Itoa(index))
+}
+
+// LockGroups dispatcher, locks the groups (all current and new groups will be
+// locked).
+func LockGroups(l GroupLock) Dispatcher {
+	return NewDispatcher("lockgroups", string(l))
+}
+
+// LockActiveGroup dispatcher, locks the current group (the current group
+// will not accept new windows or be moved to other groups).
+func LockActiveGroup(l GroupLock) Dispatcher {
+	return NewDispatcher("lockactivegroup", string(l))
+}
+
+// MoveIntoGroup dispatcher, moves the active window into a group in a
+// direction.
+func MoveIntoGroup(d Direction) Dispatcher {
+	return NewDispatcher("moveintogroup", string(d))
+}
+
+// MoveOutOfGroup dispatcher, moves a specified window (or the active one)
+// out of a group.
+func MoveOutOfGroup(w WindowSelector) Dispatcher {
+	return NewDispatcher("moveoutofgroup", string(w)).validate(optional[WindowSelector]{w})
+}
+
+// MoveWindowOrGroup dispatcher, behaves as [MoveWindow] for windows outside
+// of groups and as [MoveIntoGroup] or [MoveOutOfGroup] otherwise.
+func MoveWindowOrGroup(d Direction) Dispatcher {
+	return NewDispatcher("movewindoworgroup", string(d))
+}
+
+// MoveGroupWindow dispatcher, swaps the active window with the next
+// (forward) or previous one in a group.
+func MoveGroupWindow(forward bool) Dispatcher {
+	return NewDispatcher("movegroupwindow", forwardArg(forward))
+}
+
+// DenyWindowFromGroup dispatcher, prohibits the active window from becoming
+// or being inserted into a group.
+func DenyWindowFromGroup(s Switch) Dispatcher {
+	return NewDispatcher("denywindowfromgroup", string(s))
+}
+
+// SetIgnoreGroupLock dispatcher, temporarily enables or disables
+// binds:ignore_group_lock.
+func SetIgnoreGroupLock(s Switch) Dispatcher {
+	return NewDispatcher("setignoregrouplock", string(s))
+}
+
+// Global dispatcher, executes a Global Shortcut using the GlobalShortcuts
+// portal.
+func Global(name string) Dispatcher {
+	return NewDispatcher("global", name)
+}
+
+// Submap dispatcher, changes the current mapping group. An empty name resets
+// to the default submap.
+func Submap(name string) Dispatcher {
+	if name == "" {
+		return NewDispatcher("submap", "reset")
+	}
+
+	return NewDispatcher("submap", name)
+}
+
+// CustomEvent dispatcher, emits a custom event to the event socket in the
+// format 'custom>>data' ('event' dispatcher).
+func CustomEvent(data string) Dispatcher {
+	return NewDispatcher("event", data)
+}
+
+// Pseudo dispatcher, toggles the pseudo tiling of a specified window (or the
+// active one). Only for dwindle layout.
+func Pseudo(w WindowSelector) Dispatcher {
+	return NewDispatcher("pseudo", string(w)).validate(optional[WindowSelector]{w})
+}
+
+// ToggleSplit dispatcher, toggles the split (top/side) of the current window.
+// Only for dwindle layout.
+func ToggleSplit() Dispatcher {
+	return NewDispatcher("togglesplit", "")
+}
+
+// SwapSplit dispatcher, swaps the two halves of the split of the current
+// window. Only for dwindle layout.
+func SwapSplit() Dispatcher {
+	return NewDispatcher("swapsplit", "")
+}
+
+// Preselect dispatcher, a one-time override for the split direction. Only for
+// dwindle layout.
+func Preselect(d Direction) Dispatcher {
+	return NewDispatcher("preselect", string(d))
+}
+
+// LayoutMsg dispatcher, sends a message to the current layout, e.g.:
+// LayoutMsg("swapwithmaster master").
+func LayoutMsg(msg string) Dispatcher {
+	return NewDispatcher("layoutmsg", msg)
+}
+
+// DispatchAll sends typed dispatchers, similar to [RequestClient.Dispatch].
+// Accept multiple dispatchers at the same time, in this case it will use
+// batch mode.
+// Returns a [Response] list for each dispatcher, that may be useful for
+// further validations.
+// All dispatchers are validated before sending them, and in case of any
+// invalid dispatcher nothing is sent and an error wrapping
+// [ErrInvalidSelector] is returned.
+func (c *RequestClient) DispatchAll(dispatchers ...Dispatcher) ([]Response, error) {
+	return c.DispatchAllContext(context.Background(), dispatchers...)
+}
+
+// DispatchAllContext is like [RequestClient.DispatchAll], but with a context.
+func (c *RequestClient) DispatchAllContext(ctx context.Context, dispatchers ...Dispatcher) ([]Response, error) {
+	if err := validateDispatchers(dispatchers); err != nil {
+		return nil, err
+	}
+
+	return c.DispatchContext(ctx, dispatcherParams(dispatchers)...)
+}
+
+func validateDispatchers(dispatchers []Dispatcher) (err error) {
+	for i, d := range dispatchers {
+		if d.err != nil {
+			err = errors.Join(err, fmt.Errorf("dispatcher %d ('%s'): %w", i, d, d.err))
+		}
+	}
+
+	return err
+}
+
+// Returns a copy of the dispatcher with the validation errors from vs.
+func (d Dispatcher) validate(vs ...interface{ Validate() error }) Dispatcher {
+	for _, v := range vs {
+		if err := v.Validate(); err != nil {
+			d.err = errors.Join(d.err, err)
+		}
+	}
+
+	return d
+}
+
+func dispatcherParams(dispatchers []Dispatcher) []string {
+	params := make([]string, 0, len(dispatchers))
+	for _, d := range dispatchers {
+		params = append(params, d.String())
+	}
+
+	return params
+}
+
+// Join non-empty args with sep.
+func joinArgs(sep string, args ...string) string {
+	nonEmpty := make([]string, 0, len(args))
+
+	for _, a := range args {
+		if a != "" {
+			nonEmpty = append(nonEmpty, a)
+		}
+	}
+
+	return strings.Join(nonEmpty, sep)
+}
+
+func boolArg(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+func forwardArg(forward bool) string {
+	if forward {
+		return "f"
+	}
+
+	return "b"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
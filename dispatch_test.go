@@ -0,0 +1,130 @@
+package hyprland
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestDispatcherString(t *testing.T) {
+	tests := []struct {
+		dispatcher Dispatcher
+		want       string
+	}{
+		{Exec("kitty"), "exec kitty"},
+		{Exec("kitty", "workspace 2 silent", "float"), "exec [workspace 2 silent;float] kitty"},
+		{ExecR("kitty"), "execr kitty"},
+		{SendShortcut("SUPER", "F4", ""), "sendshortcut SUPER, F4"},
+		{SendShortcut("SUPER", "F4", "class:kitty"), "sendshortcut SUPER, F4, class:kitty"},
+		{KillActive(), "killactive"},
+		{CloseWindow("class:kitty"), "closewindow class:kitty"},
+		{SignalWindow("class:kitty", 9), "signalwindow class:kitty,9"},
+		{FocusWorkspace("r+1"), "workspace r+1"},
+		{MoveToWorkspace("2", false), "movetoworkspace 2"},
+		{MoveToWorkspace("special:scratch", true), "movetoworkspacesilent special:scratch"},
+		{MoveWindowToWorkspace("2", "address:0x1234", false), "movetoworkspace 2,address:0x1234"},
+		{ToggleFloating(""), "togglefloating"},
+		{ToggleFullscreen(true), "fullscreen 1"},
+		{SetFullscreenState(Fullscreen, None), "fullscreenstate 2 0"},
+		{DPMS(SwitchOff, ""), "dpms off"},
+		{DPMS(SwitchToggle, "DP-1"), "dpms toggle DP-1"},
+		{MoveFocus(DirectionLeft), "movefocus l"},
+		{MoveWindowToMonitor("DP-1", true), "movewindow mon:DP-1 silent"},
+		{CenterWindow(true), "centerwindow 1"},
+		{ResizeActive(10, -10), "resizeactive 10 -10"},
+		{ResizeActiveExact(800, 600), "resizeactive exact 800 600"},
+		{MoveWindowPixel(10, 20, "class:kitty"), "movewindowpixel 10 20,class:kitty"},
+		{CycleNext(true), "cyclenext prev"},
+		{FocusWindow("address:0x1234"), "focuswindow address:0x1234"},
+		{SplitRatio(-0.1), "splitratio -0.1"},
+		{SplitRatioExact(0.5), "splitratio exact 0.5"},
+		{RenameWorkspace(1, "foo"), "renameworkspace 1 foo"},
+		{MoveWorkspaceToMonitor("1", "DP-1"), "moveworkspacetomonitor 1 DP-1"},
+		{AlterZOrder(true, ""), "alterzorder top"},
+		{AlterZOrder(false, "class:kitty"), "alterzorder bottom,class:kitty"},
+		{ToggleSpecialWorkspace(""), "togglespecialworkspace"},
+		{ToggleGroup(), "togglegroup"},
+		{ChangeGroupActive(false), "changegroupactive b"},
+		{ChangeGroupActiveIndex(2), "changegroupactive 2"},
+		{LockActiveGroup(GroupLockToggle), "lockactivegroup toggle"},
+		{MoveIntoGroup(DirectionUp), "moveintogroup u"},
+		{MoveGroupWindow(true), "movegroupwindow f"},
+		{Submap(""), "submap reset"},
+		{CustomEvent("foo"), "event foo"},
+		{LayoutMsg("swapwithmaster master"), "layoutmsg swapwithmaster master"},
+		{NewDispatcher("hyprexpo:expo", "toggle"), "hyprexpo:expo toggle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.dispatcher.String(), tt.want)
+		})
+	}
+}
+
+func TestDispatcherParams(t *testing.T) {
+	params := dispatcherParams([]Dispatcher{
+		ToggleGroup(),
+		FocusWindow("address:0x1234"),
+		MoveIntoGroup(DirectionLeft),
+	})
+	assert.DeepEqual(t, params, []string{
+		"togglegroup",
+		"focuswindow address:0x1234",
+		"moveintogroup l",
+	})
+}
+
+func TestDispatcherValidate(t *testing.T) {
+	tests := []struct {
+		dispatcher Dispatcher
+		wantErr    bool
+	}{
+		{FocusWindow(WindowByAddress("0x1234")), false},
+		{FocusWindow(""), true},
+		{FocusWindow("address:foo"), true},
+		{ToggleFloating(""), false},
+		{ToggleFloating("pid:foo"), true},
+		{MoveToWorkspace("name:", false), true},
+		{MoveWindowToWorkspace("1", "", true), false},
+		{MoveWorkspaceToMonitor("1", "DP 1"), true},
+		{DPMS(SwitchOn, ""), false},
+		{NewDispatcher("whatever", "foo"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dispatcher.String(), func(t *testing.T) {
+			err := tt.dispatcher.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidSelector))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateDispatchers(t *testing.T) {
+	err := validateDispatchers([]Dispatcher{
+		ToggleGroup(),
+		FocusWindow("address:foo"),
+		FocusWorkspace("foo"),
+	})
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidSelector))
+
+	// should never write to socket, so this should work even without
+	// a client
+	var client *RequestClient
+	_, err = client.DispatchAll(FocusWindow(""))
+	assert.True(t, errors.Is(err, ErrInvalidSelector))
+}
+
+func TestDispatchAll(t *testing.T) {
+	testCommandRs(t, func() ([]Response, error) {
+		return c.DispatchAll(
+			Exec("kitty sh -c 'echo Testing hyprland-go && sleep 1 && exit 0'"),
+			CycleNext(false),
+		)
+	})
+}
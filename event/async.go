@@ -0,0 +1,242 @@
+package event
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// Ordering is the ordering policy of an [AsyncDispatcher].
+type Ordering int
+
+const (
+	// All events are handled in order by a single worker.
+	OrderGlobal Ordering = iota
+	// Events from the same window address are handled in order, while
+	// events from different windows may be handled concurrently. Events
+	// without a window address are handled in order by the same worker.
+	OrderPerWindow
+	// Events are handled concurrently without any ordering.
+	OrderNone
+)
+
+// Overflow is the strategy of an [AsyncDispatcher] when a queue is full.
+type Overflow int
+
+const (
+	// Block until there is space in the queue, e.g.: will stop reading
+	// new events.
+	OverflowBlock Overflow = iota
+	// Drop the event, see [AsyncStats].
+	OverflowDrop
+)
+
+// AsyncStats are the metrics from an [AsyncDispatcher].
+type AsyncStats struct {
+	// Number of events received by [AsyncDispatcher.Dispatch].
+	Received uint64
+	// Number of events handled.
+	Handled uint64
+	// Number of events dropped, see [OverflowDrop], or that were not
+	// queued because the context was done or the dispatcher was closed.
+	Dropped uint64
+}
+
+// AsyncOption configures an [AsyncDispatcher], see [NewAsyncDispatcher].
+type AsyncOption func(d *AsyncDispatcher)
+
+// WithOrdering sets the ordering policy, the default is [OrderGlobal].
+func WithOrdering(o Ordering) AsyncOption {
+	return func(d *AsyncDispatcher) { d.ordering = o }
+}
+
+// WithWorkers sets the number of workers, the default is 4. Ignored with
+// [OrderGlobal], that always uses a single worker.
+func WithWorkers(n int) AsyncOption {
+	return func(d *AsyncDispatcher) { d.workers = max(n, 1) }
+}
+
+// WithQueueSize sets the size of the queue of each worker, the default is
+// 100. With [OverflowDrop], a zero size drops every event that arrives while
+// the workers are busy, so it should only be used with very fast handlers.
+func WithQueueSize(n int) AsyncOption {
+	return func(d *AsyncDispatcher) { d.queueSize = max(n, 0) }
+}
+
+// WithOverflow sets the strategy when a queue is full, the default is
+// [OverflowBlock].
+func WithOverflow(o Overflow) AsyncOption {
+	return func(d *AsyncDispatcher) { d.overflow = o }
+}
+
+// AsyncDispatcher calls a function for each event in worker goroutines, so
+// slow handlers (e.g.: that do requests to Hyprland) do not stop the client
+// from reading new events, e.g.:
+//
+//	r := event.NewRouter()
+//	d := event.NewAsyncDispatcher(r.Dispatch, event.WithOrdering(event.OrderPerWindow))
+//	defer d.Close()
+//	err := d.Run(ctx, event.MustClient())
+type AsyncDispatcher struct {
+	f         func(e Event)
+	ordering  Ordering
+	workers   int
+	queueSize int
+	overflow  Overflow
+
+	// Protects against sending to closed queues: senders are tracked in
+	// sending, so the queues are only closed after all of them return
+	mu      sync.Mutex
+	closed  bool
+	done    chan struct{}
+	sending sync.WaitGroup
+	queues  []chan Event
+	wg      sync.WaitGroup
+
+	received, handled, dropped atomic.Uint64
+}
+
+// NewAsyncDispatcher creates and starts a new [AsyncDispatcher] that calls f
+// for each event, e.g.: [Router.Dispatch].
+func NewAsyncDispatcher(f func(e Event), opts ...AsyncOption) *AsyncDispatcher {
+	d := &AsyncDispatcher{
+		f:         f,
+		ordering:  OrderGlobal,
+		workers:   4,
+		queueSize: 100,
+		overflow:  OverflowBlock,
+		done:      make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	switch d.ordering {
+	case OrderGlobal:
+		d.queues = []chan Event{make(chan Event, d.queueSize)}
+		d.startWorker(d.queues[0])
+	case OrderPerWindow:
+		// One queue per worker, so events for the same window are
+		// always handled by the same worker
+		for i := 0; i < d.workers; i++ {
+			d.queues = append(d.queues, make(chan Event, d.queueSize))
+			d.startWorker(d.queues[i])
+		}
+	case OrderNone:
+		// A single queue shared by all workers
+		d.queues = []chan Event{make(chan Event, d.queueSize)}
+		for i := 0; i < d.workers; i++ {
+			d.startWorker(d.queues[0])
+		}
+	}
+
+	return d
+}
+
+func (d *AsyncDispatcher) startWorker(queue chan Event) {
+	d.wg.Add(1)
+
+	go func() {
+		defer d.wg.Done()
+
+		for e := range queue {
+			d.f(e)
+			d.handled.Add(1)
+		}
+	}()
+}
+
+// Dispatch queues an event to be handled, returning false if the event was
+// dropped, see [OverflowDrop], or if the dispatcher is closed.
+// With [OverflowBlock], this blocks until there is space in the queue or the
+// dispatcher is closed, see [AsyncDispatcher.DispatchContext].
+func (d *AsyncDispatcher) Dispatch(e Event) bool {
+	return d.DispatchContext(context.Background(), e)
+}
+
+// DispatchContext is like [AsyncDispatcher.Dispatch], but also returns false
+// if ctx is done while waiting for space in the queue.
+func (d *AsyncDispatcher) DispatchContext(ctx context.Context, e Event) bool {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		d.received.Add(1)
+		d.dropped.Add(1)
+
+		return false
+	}
+	// Do not hold the lock while sending, since it may block
+	d.sending.Add(1)
+	d.mu.Unlock()
+
+	defer d.sending.Done()
+
+	d.received.Add(1)
+
+	queue := d.queues[0]
+	if d.ordering == OrderPerWindow {
+		h := fnv.New32a()
+		h.Write([]byte(e.Address()))
+		queue = d.queues[h.Sum32()%uint32(len(d.queues))]
+	}
+
+	if d.overflow == OverflowDrop {
+		select {
+		case queue <- e:
+			return true
+		default:
+			d.dropped.Add(1)
+
+			return false
+		}
+	}
+
+	select {
+	case queue <- e:
+		return true
+	case <-ctx.Done():
+	case <-d.done:
+	}
+
+	d.dropped.Add(1)
+
+	return false
+}
+
+// Run receives events from c and dispatch them until ctx is cancelled or an
+// error happens. Malformed events are skipped.
+func (d *AsyncDispatcher) Run(ctx context.Context, c *EventClient) error {
+	return receiveEvents(ctx, c, func(e Event) { d.DispatchContext(ctx, e) })
+}
+
+// Close stops accepting new events and waits until all queued events are
+// handled. Events waiting for space in a queue are dropped.
+func (d *AsyncDispatcher) Close() {
+	d.mu.Lock()
+	closed := d.closed
+	d.closed = true
+	d.mu.Unlock()
+
+	if !closed {
+		// Unblock the senders before closing the queues
+		close(d.done)
+		d.sending.Wait()
+
+		for _, queue := range d.queues {
+			close(queue)
+		}
+	}
+
+	d.wg.Wait()
+}
+
+// Stats returns the current metrics.
+func (d *AsyncDispatcher) Stats() AsyncStats {
+	return AsyncStats{
+		Received: d.received.Load(),
+		Handled:  d.handled.Load(),
+		Dropped:  d.dropped.Load(),
+	}
+}
@@ -0,0 +1,235 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestAsyncDispatcherGlobal(t *testing.T) {
+	var got []string
+
+	d := NewAsyncDispatcher(func(e Event) {
+		got = append(got, string(e.Data.(WorkspaceName))) //nolint:forcetypeassert
+	})
+
+	var want []string
+
+	for i := 0; i < 1000; i++ {
+		want = append(want, fmt.Sprint(i))
+		assert.True(t, d.Dispatch(Event{Type: EventWorkspace, Data: WorkspaceName(fmt.Sprint(i))}))
+	}
+
+	d.Close()
+
+	assert.DeepEqual(t, got, want)
+	assert.Equal(t, d.Stats(), AsyncStats{Received: 1000, Handled: 1000})
+
+	// Closed dispatcher should not accept new events
+	assert.False(t, d.Dispatch(Event{Type: EventWorkspace, Data: WorkspaceName("1")}))
+	assert.Equal(t, d.Stats(), AsyncStats{Received: 1001, Handled: 1000, Dropped: 1})
+	d.Close()
+}
+
+func TestAsyncDispatcherPerWindow(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		got = map[hyprland.WindowAddress][]int{}
+	)
+
+	d := NewAsyncDispatcher(func(e Event) {
+		w, _ := e.Data.(WindowTitleV2)
+
+		var i int
+		fmt.Sscan(w.Title, &i)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		got[w.Address] = append(got[w.Address], i)
+	}, WithOrdering(OrderPerWindow), WithWorkers(4), WithQueueSize(1))
+
+	for i := 0; i < 1000; i++ {
+		d.Dispatch(Event{
+			Type: EventWindowTitleV2,
+			Data: WindowTitleV2{Address: hyprland.WindowAddress(fmt.Sprintf("0x%x", i%10)), Title: fmt.Sprint(i)},
+		})
+	}
+
+	d.Close()
+
+	assert.Equal(t, len(got), 10)
+
+	for addr, seq := range got {
+		assert.Equal(t, len(seq), 100)
+
+		for i := 1; i < len(seq); i++ {
+			if seq[i-1] > seq[i] {
+				t.Errorf("events out of order for address %s: %v", addr, seq)
+			}
+		}
+	}
+}
+
+func TestAsyncDispatcherNone(t *testing.T) {
+	const workers = 4
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	// All events need to be handled at the same time for this to finish
+	d := NewAsyncDispatcher(func(Event) {
+		wg.Done()
+		wg.Wait()
+	}, WithOrdering(OrderNone), WithWorkers(workers))
+
+	for i := 0; i < workers; i++ {
+		d.Dispatch(Event{Type: EventConfigReloaded})
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		d.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("events were not handled concurrently")
+	}
+}
+
+func TestAsyncDispatcherDrop(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	d := NewAsyncDispatcher(func(Event) {
+		started <- struct{}{}
+		<-release
+	}, WithQueueSize(1), WithOverflow(OverflowDrop))
+
+	e := Event{Type: EventConfigReloaded}
+
+	// Wait until the worker is busy with the first event
+	assert.True(t, d.Dispatch(e))
+	<-started
+
+	// Fill the queue
+	assert.True(t, d.Dispatch(e))
+
+	for i := 0; i < 5; i++ {
+		assert.False(t, d.Dispatch(e))
+	}
+
+	close(release)
+
+	go func() {
+		for range started {
+		}
+	}()
+
+	d.Close()
+	close(started)
+
+	assert.Equal(t, d.Stats(), AsyncStats{Received: 7, Handled: 2, Dropped: 5})
+}
+
+func TestAsyncDispatcherBlock(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	d := NewAsyncDispatcher(func(Event) {
+		started <- struct{}{}
+		<-release
+	}, WithQueueSize(1))
+
+	e := Event{Type: EventConfigReloaded}
+
+	// Wait until the worker is busy with the first event, then fill the
+	// queue
+	assert.True(t, d.Dispatch(e))
+	<-started
+	assert.True(t, d.Dispatch(e))
+
+	// A full queue blocks until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.False(t, d.DispatchContext(ctx, e))
+
+	// Or until the dispatcher is closed
+	blocked := make(chan bool)
+
+	go func() { blocked <- d.Dispatch(e) }()
+
+	for d.Stats().Received < 4 {
+		time.Sleep(time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+
+	go func() {
+		d.Close()
+		close(closed)
+	}()
+
+	select {
+	case ok := <-blocked:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout while waiting for blocked dispatch")
+	}
+
+	close(release)
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout while waiting for close")
+	}
+
+	assert.Equal(t, d.Stats(), AsyncStats{Received: 4, Handled: 2, Dropped: 2})
+}
+
+func TestAsyncDispatcherRun(t *testing.T) {
+	c, server := pipeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	titles := make(chan string, 10)
+
+	r := NewRouter()
+	r.OnWindowTitleV2(func(w WindowTitleV2) { titles <- w.Title })
+
+	d := NewAsyncDispatcher(r.Dispatch, WithOrdering(OrderPerWindow))
+	defer d.Close()
+
+	errCh := make(chan error, 1)
+
+	go func() { errCh <- d.Run(ctx, c) }()
+
+	go server.Write([]byte("windowtitlev2>>80e62df0,a\nwindowtitlev2>>80e62df0,b\n"))
+
+	for _, want := range []string{"a", "b"} {
+		select {
+		case title := <-titles:
+			assert.Equal(t, title, want)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout while waiting for events")
+		}
+	}
+
+	cancel()
+
+	assert.True(t, errors.Is(<-errCh, context.Canceled))
+}
@@ -1,13 +1,16 @@
 package event
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/thiagokokada/hyprland-go"
 	"github.com/thiagokokada/hyprland-go/helpers"
 	"github.com/thiagokokada/hyprland-go/internal/assert"
 )
@@ -17,32 +20,83 @@ const (
 	sep     = ">>"
 )
 
+var errClosed = errors.New("client is closed")
+
 // Initiate a new client or panic.
 // This should be the preferred method for user scripts, since it will
 // automatically find the proper socket to connect and use the
 // HYPRLAND_INSTANCE_SIGNATURE for the current user.
 // If you need to connect to arbitrary user instances or need a method that
 // will not panic on error, use [NewClient] instead.
-func MustClient() *EventClient {
+// When reconnecting (see [WithReconnect]), the socket is found again using
+// the current HYPRLAND_INSTANCE_SIGNATURE.
+func MustClient(opts ...ClientOption) *EventClient {
+	resolver := WithSocketResolver(func() (string, error) {
+		return helpers.GetSocket(helpers.EventSocket)
+	})
+
 	return assert.Must1(NewClient(
-		assert.Must1(helpers.GetSocket(helpers.EventSocket))),
-	)
+		assert.Must1(helpers.GetSocket(helpers.EventSocket)),
+		append([]ClientOption{resolver}, opts...)...,
+	))
 }
 
 // Initiate a new event client.
 // Receive as parameters a socket that is generally localised in
 // '$XDG_RUNTIME_DIR/hypr/$HYPRLAND_INSTANCE_SIGNATURE/.socket2.sock'.
-func NewClient(socket string) (*EventClient, error) {
+func NewClient(socket string, opts ...ClientOption) (*EventClient, error) {
+	c := &EventClient{
+		resolve: func() (string, error) { return socket, nil },
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	conn, err := net.Dial("unix", socket)
 	if err != nil {
 		return nil, fmt.Errorf("error while connecting to socket: %w", err)
 	}
 
-	return &EventClient{conn: conn}, err
+	c.conn = conn
+
+	return c, err
+}
+
+// ClientOption configures an [EventClient], see [NewClient].
+type ClientOption func(c *EventClient)
+
+// WithReconnect enables automatic reconnection when the connection is lost,
+// e.g.: when Hyprland restarts. The reconnection is retried with an
+// exponential backoff starting from minDelay up to maxDelay, until it
+// succeeds or the context is cancelled.
+// After reconnecting, an [EventReconnected] event is received, since events
+// may have been missed in the meantime.
+func WithReconnect(minDelay, maxDelay time.Duration) ClientOption {
+	return func(c *EventClient) {
+		c.reconnect = true
+		c.minDelay = minDelay
+		c.maxDelay = max(minDelay, maxDelay)
+	}
+}
+
+// WithSocketResolver sets the function used to find the socket when
+// reconnecting, since the socket may change, e.g.: after Hyprland restarts.
+// The default is to use the same socket passed to [NewClient].
+func WithSocketResolver(f func() (string, error)) ClientOption {
+	return func(c *EventClient) { c.resolve = f }
 }
 
 // Close the underlying connection.
 func (c *EventClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The connection is already closed while reconnecting
+	if ConnState(c.state.Swap(int32(StateClosed))) == StateReconnecting {
+		return nil
+	}
+
 	err := c.conn.Close()
 	if err != nil {
 		return fmt.Errorf("error while closing socket: %w", err)
@@ -51,43 +105,139 @@ func (c *EventClient) Close() error {
 	return err
 }
 
+// State returns the current state of the connection. While
+// [StateReconnecting], events are lost.
+func (c *EventClient) State() ConnState {
+	return ConnState(c.state.Load())
+}
+
+func (c *EventClient) getConn() net.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn
+}
+
+// Reconnect to the socket with exponential backoff.
+func (c *EventClient) reconnectWithBackoff(ctx context.Context) error {
+	// May be already reconnecting, e.g.: the context of a previous call
+	// was cancelled
+	if c.state.CompareAndSwap(int32(StateConnected), int32(StateReconnecting)) {
+		c.getConn().Close()
+	} else if c.State() == StateClosed {
+		return errClosed
+	}
+
+	delay := c.minDelay
+
+	for {
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if c.State() == StateClosed {
+			return errClosed
+		}
+
+		delay = min(delay*2, c.maxDelay)
+
+		socket, err := c.resolve()
+		if err != nil {
+			continue
+		}
+
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		if !c.state.CompareAndSwap(int32(StateReconnecting), int32(StateConnected)) {
+			c.mu.Unlock()
+			conn.Close()
+
+			return errClosed
+		}
+
+		c.conn = conn
+		c.pending = nil
+		c.mu.Unlock()
+
+		return nil
+	}
+}
+
 // Low-level receive event method, should be avoided unless there is no
 // alternative.
+// Blocks until at least one complete event line is received. Partial lines
+// are kept between calls, so events split across multiple reads are not
+// lost. Not safe for concurrent use.
 func (c *EventClient) Receive(ctx context.Context) ([]ReceivedData, error) {
 	buf := make([]byte, bufSize)
 
-	n, err := readWithContext(ctx, c.conn, buf)
-	if err != nil {
-		return nil, fmt.Errorf("error while reading from socket: %w", err)
-	}
+	for {
+		// Process all complete lines, keeping the remaining partial line
+		// for the next call
+		if i := bytes.LastIndexByte(c.pending, '\n'); i >= 0 {
+			lines := string(c.pending[:i])
+			c.pending = append(c.pending[:0], c.pending[i+1:]...)
+
+			return parseReceivedData(lines), nil
+		}
+
+		n, err := readWithContext(ctx, c.getConn(), buf)
+		if err != nil {
+			if !c.reconnect || ctx.Err() != nil || c.State() == StateClosed {
+				return nil, fmt.Errorf("error while reading from socket: %w", err)
+			}
+
+			if err := c.reconnectWithBackoff(ctx); err != nil {
+				return nil, fmt.Errorf("error while reconnecting to socket: %w", err)
+			}
+
+			return []ReceivedData{{Type: EventReconnected}}, nil
+		}
 
-	buf = buf[:n]
+		c.pending = append(c.pending, buf[:n]...)
+	}
+}
 
+func parseReceivedData(lines string) []ReceivedData {
 	var recv []ReceivedData //nolint:prealloc
 
-	raw := strings.Split(string(buf), "\n")
-	for _, event := range raw {
+	for _, event := range strings.Split(lines, "\n") {
 		if event == "" {
 			continue
 		}
 
-		split := strings.Split(event, sep)
-		if len(split) < 2 || split[0] == "" || split[1] == "" || split[1] == "," {
+		// Data may be empty, e.g.: 'configreloaded>>' or 'submap>>'
+		eventType, data, found := strings.Cut(event, sep)
+		if !found || eventType == "" {
 			continue
 		}
 
 		recv = append(recv, ReceivedData{
-			Type: EventType(split[0]),
-			Data: RawData(split[1]),
+			Type: EventType(eventType),
+			Data: RawData(data),
 		})
 	}
 
-	return recv, nil
+	return recv
 }
 
 // Subscribe to events.
 // You need to pass an implementation of [EventHandler] interface for each of
 // the events you want to handle and all event types you want to handle.
+// Malformed events are skipped, use [EventClient.Receive] and [ParseEvent]
+// if you need to handle them.
+// If ev implements [ReconnectedHandler], it will be notified after the client
+// reconnects, see [WithReconnect].
 func (c *EventClient) Subscribe(ctx context.Context, ev EventHandler, events ...EventType) error {
 	for {
 		// Process an event
@@ -97,19 +247,70 @@ func (c *EventClient) Subscribe(ctx context.Context, ev EventHandler, events ...
 	}
 }
 
+// Events returns a channel that receives the parsed events, as an
+// alternative to [EventClient.Subscribe] that can be used in select loops.
+// Only the event types in events are sent, or all events (including unknown
+// ones) if events is empty. [EventReconnected] is always sent. The channel
+// has the given buffer size, and malformed events are skipped.
+// Once ctx is cancelled or an error happens, the error is sent to the error
+// channel and both channels are closed.
+// The client should not be used for anything else while the channels are
+// open.
+func (c *EventClient) Events(ctx context.Context, buffer int, events ...EventType) (<-chan Event, <-chan error) {
+	ch := make(chan Event, buffer)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+
+		for {
+			msg, err := c.Receive(ctx)
+			if err != nil {
+				errCh <- fmt.Errorf("event processing: %w", err)
+
+				return
+			}
+
+			for _, data := range msg {
+				if len(events) > 0 && !slices.Contains(events, data.Type) && data.Type != EventReconnected {
+					continue
+				}
+
+				e, err := ParseEvent(data)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					errCh <- fmt.Errorf("event processing: %w", ctx.Err())
+
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, errCh
+}
+
 func readWithContext(ctx context.Context, conn net.Conn, buf []byte) (n int, err error) {
+	var readErr error
+
 	done := make(chan struct{})
 
 	// Start a goroutine to perform the read
 	go func() {
-		n, err = conn.Read(buf)
+		n, readErr = conn.Read(buf)
 
 		close(done)
 	}()
 
 	select {
 	case <-done:
-		return n, err
+		return n, readErr
 	case <-ctx.Done():
 		// Set a short deadline to unblock the Read()
 		err = conn.SetReadDeadline(time.Now())
@@ -125,7 +326,13 @@ func readWithContext(ctx context.Context, conn net.Conn, buf []byte) (n int, err
 		// Make sure that the goroutine is done to avoid leaks
 		<-done
 
-		return 0, errors.Join(err, ctx.Err())
+		// The read may have finished before the deadline, so make
+		// sure the data is not lost
+		if n > 0 {
+			return n, nil
+		}
+
+		return 0, errors.Join(readErr, ctx.Err())
 	}
 }
 
@@ -143,201 +350,450 @@ func receiveAndProcessEvent(ctx context.Context, c eventClient, ev EventHandler,
 }
 
 func processEvent(ev EventHandler, msg ReceivedData, events []EventType) {
-	for _, event := range events {
-		raw := strings.Split(string(msg.Data), ",")
-		if msg.Type == event {
-			switch event {
-			case EventWorkspace:
-				// e.g. "1" (workspace number)
-				ev.Workspace(WorkspaceName(raw[0]))
-			case EventFocusedMonitor:
-				// idk
-				ev.FocusedMonitor(FocusedMonitor{
-					MonitorName:   MonitorName(raw[0]),
-					WorkspaceName: WorkspaceName(raw[1]),
-				})
-			case EventActiveWindow:
-				ev.ActiveWindow(ActiveWindow{
-					Name:  raw[0],
-					Title: raw[1],
-				})
-			case EventFullscreen:
-				// e.g. "true" or "false"
-				ev.Fullscreen(raw[0] == "1")
-			case EventMonitorRemoved:
-				// e.g. idk
-				ev.MonitorRemoved(MonitorName(raw[0]))
-			case EventMonitorAdded:
-				// e.g. idk
-				ev.MonitorAdded(MonitorName(raw[0]))
-			case EventCreateWorkspace:
-				// e.g. "1" (workspace number)
-				ev.CreateWorkspace(WorkspaceName(raw[0]))
-			case EventDestroyWorkspace:
-				// e.g. "1" (workspace number)
-				ev.DestroyWorkspace(WorkspaceName(raw[0]))
-			case EventMoveWorkspace:
-				// e.g. idk
-				ev.MoveWorkspace(MoveWorkspace{
-					WorkspaceName: WorkspaceName(raw[0]),
-					MonitorName:   MonitorName(raw[1]),
-				})
-			case EventActiveLayout:
-				// e.g. AT Translated Set 2 keyboard,Russian
-				ev.ActiveLayout(ActiveLayout{
-					Type: raw[0],
-					Name: raw[1],
-				})
-			case EventOpenWindow:
-				// e.g. 80864f60,1,Alacritty,Alacritty
-				ev.OpenWindow(OpenWindow{
-					Address:       raw[0],
-					WorkspaceName: WorkspaceName(raw[1]),
-					Class:         raw[2],
-					Title:         raw[3],
-				})
-			case EventCloseWindow:
-				// e.g. 5
-				ev.CloseWindow(CloseWindow{
-					Address: raw[0],
-				})
-			case EventMoveWindow:
-				// e.g. 5
-				ev.MoveWindow(MoveWindow{
-					Address:       raw[0],
-					WorkspaceName: WorkspaceName(raw[1]),
-				})
-			case EventOpenLayer:
-				// e.g. wofi
-				ev.OpenLayer(OpenLayer(raw[0]))
-			case EventCloseLayer:
-				// e.g. wofi
-				ev.CloseLayer(CloseLayer(raw[0]))
-			case EventSubMap:
-				// e.g. idk
-				ev.SubMap(SubMap(raw[0]))
-			case EventScreencast:
-				ev.Screencast(Screencast{
-					Sharing: raw[0] == "1",
-					Owner:   raw[1],
-				})
-			case EventToggleGroup:
-				ev.ToggleGroup(ToggleGroup{
-					Toggle:  raw[0] == "1",
-					Address: raw[1],
-				})
-			case EventMoveOutofGroup:
-				ev.MoveOutofGroup(MoveOutofGroup{
-					Address: raw[0],
-				})
-			case EventMoveIntogroup:
-				ev.MoveIntogroup(MoveIntogroup{
-					Address: raw[0],
-				})
-			case EventIgnoreGroupLock:
-				ev.IgnoreGroupLock(raw[0] == "1")
-			case EventLockGroups:
-				ev.LockGroups(raw[0] == "1")
-			case EventActiveWindowV2:
-				ev.ActiveWindow(ActiveWindow{
-					Name:  raw[0],
-					Title: "",
-				})
-			case EventWorkspaceV2:
-				ev.WorkspaceV2(WorkspaceV2{
-					ID:   raw[0],
-					Name: WorkspaceName(raw[1]),
-				})
-			case EventFocusedMonitorV2:
-				ev.FocusedMonitorV2(FocusedMonitorV2{
-					MonitorName: MonitorName(raw[0]),
-					WorkspaceID: raw[1],
-				})
-			case EventMonitorRemovedV2:
-				ev.MonitorRemovedV2(MonitorRemovedV2{
-					ID:          raw[0],
-					Name:        MonitorName(raw[1]),
-					Description: raw[2],
-				})
-			case EventMonitorAddedV2:
-				ev.MonitorAddedV2(MonitorAddedV2{
-					ID:          raw[0],
-					Name:        MonitorName(raw[1]),
-					Description: raw[2],
-				})
-			case EventCreateWorkspaceV2:
-				ev.CreateWorkspaceV2(CreateWorkspaceV2{
-					ID:   raw[0],
-					Name: WorkspaceName(raw[1]),
-				})
-			case EventDestroyWorkspaceV2:
-				ev.DestroyWorkspaceV2(DestroyWorkspaceV2{
-					ID:   raw[0],
-					Name: WorkspaceName(raw[1]),
-				})
-			case EventMoveWorkspaceV2:
-				ev.MoveWorkspaceV2(MoveWorkspaceV2{
-					ID:          raw[0],
-					Name:        WorkspaceName(raw[1]),
-					MonitorName: MonitorName(raw[2]),
-				})
-			case EventRenameWorkspace:
-				ev.RenameWorkspace(RenameWorkspace{
-					ID:      raw[0],
-					NewName: WorkspaceName(raw[1]),
-				})
-			case EventActiveSpecial:
-				ev.ActiveSpecial(ActiveSpecial{
-					Name:        WorkspaceName(raw[0]),
-					MonitorName: MonitorName(raw[1]),
-				})
-			case EventActiveSpecialV2:
-				ev.ActiveSpecialV2(ActiveSpecialV2{
-					ID:          raw[0],
-					Name:        WorkspaceName(raw[1]),
-					MonitorName: MonitorName(raw[2]),
-				})
-			case EventMoveWindowV2:
-				ev.MoveWindowV2(MoveWindowV2{
-					Address:       raw[0],
-					WorkspaceID:   raw[1],
-					WorkspaceName: WorkspaceName(raw[2]),
-				})
-			case EventChangeFloatingMode:
-				ev.ChangeFloatingMode(ChangeFloatingMode{
-					Address:  raw[0],
-					Floating: raw[1] == "1",
-				})
-			case EventUrgent:
-				ev.Urgent(Urgent{
-					Address: raw[0],
-				})
-			case EventWindowTitle:
-				ev.WindowTitle(WindowTitle{
-					Address: raw[0],
-				})
-			case EventWindowTitleV2:
-				ev.WindowTitleV2(WindowTitleV2{
-					Address: raw[0],
-					Title:   raw[1],
-				})
-			case EventConfigReloaded:
-				ev.ConfigReloaded()
-			case EventPin:
-				ev.Pin(Pin{
-					Address: raw[0],
-					Pinned:  raw[1] == "1",
-				})
-			case EventMinimize:
-				ev.Minimize(Minimize{
-					Address:   raw[0],
-					Minimized: raw[1] == "1",
-				})
-			case EventBell:
-				ev.Bell(Bell{
-					Address: raw[0],
-				})
-			}
+	// Unknown events are always sent to the catch-all handler, since they
+	// can not be part of events
+	u, ok := ev.(UnknownEventHandler)
+	if ok && !slices.Contains(AllEvents, msg.Type) && msg.Type != EventReconnected {
+		u.Unknown(msg)
+
+		return
+	}
+
+	if !slices.Contains(events, msg.Type) && msg.Type != EventReconnected {
+		return
+	}
+
+	e, err := ParseEvent(msg)
+	if err != nil {
+		// Skip malformed events, since there is nothing the handler
+		// could do about them
+		return
+	}
+
+	dispatchEvent(ev, e)
+}
+
+// Calls the respective ev method for a parsed event.
+func dispatchEvent(ev EventHandler, e Event) {
+	switch e.Type {
+	case EventWorkspace:
+		call(e.Data, ev.Workspace)
+	case EventFocusedMonitor:
+		call(e.Data, ev.FocusedMonitor)
+	case EventActiveWindow:
+		call(e.Data, ev.ActiveWindow)
+	case EventFullscreen:
+		call(e.Data, ev.Fullscreen)
+	case EventMonitorRemoved:
+		call(e.Data, ev.MonitorRemoved)
+	case EventMonitorAdded:
+		call(e.Data, ev.MonitorAdded)
+	case EventCreateWorkspace:
+		call(e.Data, ev.CreateWorkspace)
+	case EventDestroyWorkspace:
+		call(e.Data, ev.DestroyWorkspace)
+	case EventMoveWorkspace:
+		call(e.Data, ev.MoveWorkspace)
+	case EventActiveLayout:
+		call(e.Data, ev.ActiveLayout)
+	case EventOpenWindow:
+		call(e.Data, ev.OpenWindow)
+	case EventCloseWindow:
+		call(e.Data, ev.CloseWindow)
+	case EventMoveWindow:
+		call(e.Data, ev.MoveWindow)
+	case EventOpenLayer:
+		call(e.Data, ev.OpenLayer)
+	case EventCloseLayer:
+		call(e.Data, ev.CloseLayer)
+	case EventSubMap:
+		call(e.Data, ev.SubMap)
+	case EventScreencast:
+		call(e.Data, ev.Screencast)
+	case EventToggleGroup:
+		call(e.Data, ev.ToggleGroup)
+	case EventMoveOutofGroup:
+		call(e.Data, ev.MoveOutofGroup)
+	case EventMoveIntogroup:
+		call(e.Data, ev.MoveIntogroup)
+	case EventIgnoreGroupLock:
+		call(e.Data, ev.IgnoreGroupLock)
+	case EventLockGroups:
+		call(e.Data, ev.LockGroups)
+	case EventActiveWindowV2:
+		call(e.Data, ev.ActiveWindowV2)
+	case EventWorkspaceV2:
+		call(e.Data, ev.WorkspaceV2)
+	case EventFocusedMonitorV2:
+		call(e.Data, ev.FocusedMonitorV2)
+	case EventMonitorRemovedV2:
+		call(e.Data, ev.MonitorRemovedV2)
+	case EventMonitorAddedV2:
+		call(e.Data, ev.MonitorAddedV2)
+	case EventCreateWorkspaceV2:
+		call(e.Data, ev.CreateWorkspaceV2)
+	case EventDestroyWorkspaceV2:
+		call(e.Data, ev.DestroyWorkspaceV2)
+	case EventMoveWorkspaceV2:
+		call(e.Data, ev.MoveWorkspaceV2)
+	case EventRenameWorkspace:
+		call(e.Data, ev.RenameWorkspace)
+	case EventActiveSpecial:
+		call(e.Data, ev.ActiveSpecial)
+	case EventActiveSpecialV2:
+		call(e.Data, ev.ActiveSpecialV2)
+	case EventMoveWindowV2:
+		call(e.Data, ev.MoveWindowV2)
+	case EventChangeFloatingMode:
+		call(e.Data, ev.ChangeFloatingMode)
+	case EventUrgent:
+		call(e.Data, ev.Urgent)
+	case EventWindowTitle:
+		call(e.Data, ev.WindowTitle)
+	case EventWindowTitleV2:
+		call(e.Data, ev.WindowTitleV2)
+	case EventConfigReloaded:
+		ev.ConfigReloaded()
+	case EventPin:
+		call(e.Data, ev.Pin)
+	case EventMinimize:
+		call(e.Data, ev.Minimize)
+	case EventBell:
+		call(e.Data, ev.Bell)
+	case EventCustom:
+		call(e.Data, ev.Custom)
+	case EventReconnected:
+		if r, ok := ev.(ReconnectedHandler); ok {
+			r.Reconnected()
+		}
+	}
+}
+
+// Calls f if data has the expected type.
+func call[T any](data any, f func(T)) {
+	if v, ok := data.(T); ok {
+		f(v)
+	}
+}
+
+// Number of comma separated fields for each event, and the index of the
+// field that may contain commas, e.g.: window titles.
+var eventFields = map[EventType]struct{ n, free int }{
+	// e.g. MONNAME,WORKSPACENAME
+	EventFocusedMonitor: {2, 1},
+	// e.g. WINDOWCLASS,WINDOWTITLE
+	EventActiveWindow: {2, 1},
+	// e.g. WORKSPACENAME,MONNAME
+	EventMoveWorkspace: {2, 0},
+	// e.g. KEYBOARDNAME,LAYOUTNAME
+	EventActiveLayout: {2, 1},
+	// e.g. WINDOWADDRESS,WORKSPACENAME,WINDOWCLASS,WINDOWTITLE
+	EventOpenWindow: {4, 3},
+	// e.g. WINDOWADDRESS,WORKSPACENAME
+	EventMoveWindow: {2, 1},
+	// e.g. STATE,OWNER
+	EventScreencast: {2, 1},
+	// e.g. 0,WINDOWADDRESS,WINDOWADDRESS
+	EventToggleGroup: {2, 1},
+	// e.g. WORKSPACEID,WORKSPACENAME
+	EventWorkspaceV2: {2, 1},
+	// e.g. MONNAME,WORKSPACEID
+	EventFocusedMonitorV2: {2, 0},
+	// e.g. MONITORID,MONITORNAME,MONITORDESCRIPTION
+	EventMonitorRemovedV2: {3, 2},
+	// e.g. MONITORID,MONITORNAME,MONITORDESCRIPTION
+	EventMonitorAddedV2: {3, 2},
+	// e.g. WORKSPACEID,WORKSPACENAME
+	EventCreateWorkspaceV2: {2, 1},
+	// e.g. WORKSPACEID,WORKSPACENAME
+	EventDestroyWorkspaceV2: {2, 1},
+	// e.g. WORKSPACEID,WORKSPACENAME,MONNAME
+	EventMoveWorkspaceV2: {3, 1},
+	// e.g. WORKSPACEID,NEWNAME
+	EventRenameWorkspace: {2, 1},
+	// e.g. WORKSPACENAME,MONNAME
+	EventActiveSpecial: {2, 0},
+	// e.g. WORKSPACEID,WORKSPACENAME,MONNAME
+	EventActiveSpecialV2: {3, 1},
+	// e.g. WINDOWADDRESS,WORKSPACEID,WORKSPACENAME
+	EventMoveWindowV2: {3, 2},
+	// e.g. WINDOWADDRESS,FLOATING
+	EventChangeFloatingMode: {2, 1},
+	// e.g. WINDOWADDRESS,WINDOWTITLE
+	EventWindowTitleV2: {2, 1},
+	// e.g. WINDOWADDRESS,PINSTATE
+	EventPin: {2, 1},
+	// e.g. WINDOWADDRESS,MINIMIZED
+	EventMinimize: {2, 1},
+}
+
+// ParseEvent parses a received event, returning an [Event] where the data
+// has the same type received by the respective [EventHandler] method.
+// Unknown events are returned with the data as [RawData].
+// Returns a [*ParseError] if the event is malformed, e.g.: missing fields.
+func ParseEvent(msg ReceivedData) (Event, error) {
+	e := Event{Type: msg.Type}
+
+	// Events without data are handled as single field events
+	spec, ok := eventFields[msg.Type]
+	if !ok {
+		spec.n = 1
+	}
+
+	raw, err := splitFields(string(msg.Data), spec.n, spec.free)
+	if err != nil {
+		return e, &ParseError{Event: msg, Reason: err.Error()}
+	}
+
+	// Parse typed fields, keeping the first error
+	var fieldErr error
+
+	setErr := func(err error) {
+		if fieldErr == nil {
+			fieldErr = err
+		}
+	}
+
+	// Booleans should be either "0" or "1"
+	parseBool := func(s string) bool {
+		if s != "0" && s != "1" {
+			setErr(fmt.Errorf("invalid boolean: %q", s)) //nolint:err113
+		}
+
+		return s == "1"
+	}
+
+	// Addresses may be empty, e.g.: 'activewindowv2>>' without an active
+	// window
+	parseAddress := func(s string) hyprland.WindowAddress {
+		if s == "" {
+			return ""
+		}
+
+		a, err := hyprland.ParseWindowAddress(s)
+		setErr(err)
+
+		return a
+	}
+
+	parseWorkspaceID := func(s string) hyprland.WorkspaceID {
+		id, err := hyprland.ParseWorkspaceID(s)
+		setErr(err)
+
+		return id
+	}
+
+	parseMonitorID := func(s string) hyprland.MonitorID {
+		id, err := hyprland.ParseMonitorID(s)
+		setErr(err)
+
+		return id
+	}
+
+	switch msg.Type {
+	case EventWorkspace:
+		e.Data = WorkspaceName(raw[0])
+	case EventFocusedMonitor:
+		e.Data = FocusedMonitor{
+			MonitorName:   MonitorName(raw[0]),
+			WorkspaceName: WorkspaceName(raw[1]),
+		}
+	case EventActiveWindow:
+		e.Data = ActiveWindow{Name: raw[0], Title: raw[1]}
+	case EventFullscreen:
+		e.Data = Fullscreen(parseBool(raw[0]))
+	case EventMonitorRemoved:
+		e.Data = MonitorName(raw[0])
+	case EventMonitorAdded:
+		e.Data = MonitorName(raw[0])
+	case EventCreateWorkspace:
+		e.Data = WorkspaceName(raw[0])
+	case EventDestroyWorkspace:
+		e.Data = WorkspaceName(raw[0])
+	case EventMoveWorkspace:
+		e.Data = MoveWorkspace{
+			WorkspaceName: WorkspaceName(raw[0]),
+			MonitorName:   MonitorName(raw[1]),
+		}
+	case EventActiveLayout:
+		e.Data = ActiveLayout{Type: raw[0], Name: raw[1]}
+	case EventOpenWindow:
+		e.Data = OpenWindow{
+			Address:       parseAddress(raw[0]),
+			WorkspaceName: WorkspaceName(raw[1]),
+			Class:         raw[2],
+			Title:         raw[3],
+		}
+	case EventCloseWindow:
+		e.Data = CloseWindow{Address: parseAddress(raw[0])}
+	case EventMoveWindow:
+		e.Data = MoveWindow{
+			Address:       parseAddress(raw[0]),
+			WorkspaceName: WorkspaceName(raw[1]),
+		}
+	case EventOpenLayer:
+		e.Data = OpenLayer(raw[0])
+	case EventCloseLayer:
+		e.Data = CloseLayer(raw[0])
+	case EventSubMap:
+		e.Data = SubMap(raw[0])
+	case EventScreencast:
+		e.Data = Screencast{Sharing: parseBool(raw[0]), Owner: ScreencastOwner(raw[1])}
+	case EventToggleGroup:
+		t := ToggleGroup{Toggle: parseBool(raw[0])}
+		for _, a := range strings.Split(raw[1], ",") {
+			t.Addresses = append(t.Addresses, parseAddress(a))
+		}
+
+		e.Data = t
+	case EventMoveOutofGroup:
+		e.Data = MoveOutofGroup{Address: parseAddress(raw[0])}
+	case EventMoveIntogroup:
+		e.Data = MoveIntogroup{Address: parseAddress(raw[0])}
+	case EventIgnoreGroupLock:
+		e.Data = IgnoreGroupLock(parseBool(raw[0]))
+	case EventLockGroups:
+		e.Data = LockGroups(parseBool(raw[0]))
+	case EventActiveWindowV2:
+		e.Data = ActiveWindowV2{Address: parseAddress(raw[0])}
+	case EventWorkspaceV2:
+		e.Data = WorkspaceV2{ID: parseWorkspaceID(raw[0]), Name: WorkspaceName(raw[1])}
+	case EventFocusedMonitorV2:
+		e.Data = FocusedMonitorV2{
+			MonitorName: MonitorName(raw[0]),
+			WorkspaceID: parseWorkspaceID(raw[1]),
+		}
+	case EventMonitorRemovedV2:
+		e.Data = MonitorRemovedV2{
+			ID:          parseMonitorID(raw[0]),
+			Name:        MonitorName(raw[1]),
+			Description: raw[2],
+		}
+	case EventMonitorAddedV2:
+		e.Data = MonitorAddedV2{
+			ID:          parseMonitorID(raw[0]),
+			Name:        MonitorName(raw[1]),
+			Description: raw[2],
+		}
+	case EventCreateWorkspaceV2:
+		e.Data = CreateWorkspaceV2{ID: parseWorkspaceID(raw[0]), Name: WorkspaceName(raw[1])}
+	case EventDestroyWorkspaceV2:
+		e.Data = DestroyWorkspaceV2{ID: parseWorkspaceID(raw[0]), Name: WorkspaceName(raw[1])}
+	case EventMoveWorkspaceV2:
+		e.Data = MoveWorkspaceV2{
+			ID:          parseWorkspaceID(raw[0]),
+			Name:        WorkspaceName(raw[1]),
+			MonitorName: MonitorName(raw[2]),
+		}
+	case EventRenameWorkspace:
+		e.Data = RenameWorkspace{ID: parseWorkspaceID(raw[0]), NewName: WorkspaceName(raw[1])}
+	case EventActiveSpecial:
+		e.Data = ActiveSpecial{
+			Name:        WorkspaceName(raw[0]),
+			MonitorName: MonitorName(raw[1]),
 		}
+	case EventActiveSpecialV2:
+		a := ActiveSpecialV2{Name: WorkspaceName(raw[1]), MonitorName: MonitorName(raw[2])}
+		// The ID is empty when the special workspace closes
+		if raw[0] != "" {
+			a.ID = parseWorkspaceID(raw[0])
+		}
+
+		e.Data = a
+	case EventMoveWindowV2:
+		e.Data = MoveWindowV2{
+			Address:       parseAddress(raw[0]),
+			WorkspaceID:   parseWorkspaceID(raw[1]),
+			WorkspaceName: WorkspaceName(raw[2]),
+		}
+	case EventChangeFloatingMode:
+		e.Data = ChangeFloatingMode{Address: parseAddress(raw[0]), Floating: parseBool(raw[1])}
+	case EventUrgent:
+		e.Data = Urgent{Address: parseAddress(raw[0])}
+	case EventWindowTitle:
+		e.Data = WindowTitle{Address: parseAddress(raw[0])}
+	case EventWindowTitleV2:
+		e.Data = WindowTitleV2{Address: parseAddress(raw[0]), Title: raw[1]}
+	case EventConfigReloaded, EventReconnected:
+		e.Data = nil
+	case EventPin:
+		e.Data = Pin{Address: parseAddress(raw[0]), Pinned: parseBool(raw[1])}
+	case EventMinimize:
+		e.Data = Minimize{Address: parseAddress(raw[0]), Minimized: parseBool(raw[1])}
+	case EventBell:
+		e.Data = Bell{Address: parseAddress(raw[0])}
+	case EventCustom:
+		e.Data = Custom(raw[0])
+	default:
+		e.Data = msg.Data
+	}
+
+	if fieldErr != nil {
+		return Event{Type: msg.Type}, &ParseError{Event: msg, Reason: fieldErr.Error()}
 	}
+
+	return e, nil
+}
+
+// Split data in n comma separated fields, where the field at index free
+// may contain commas.
+func splitFields(data string, n int, free int) ([]string, error) {
+	// Fields before the free field
+	fields := strings.SplitN(data, ",", free+1)
+	if len(fields) < free+1 {
+		return nil, fmt.Errorf("want %d fields, got %d", n, len(fields)) //nolint:err113
+	}
+
+	// Fields after the free field, from right to left
+	rest := fields[free]
+	after := make([]string, n-free-1)
+
+	for i := len(after) - 1; i >= 0; i-- {
+		j := strings.LastIndexByte(rest, ',')
+		if j < 0 {
+			return nil, fmt.Errorf("want %d fields, got %d", n, free+len(after)-i) //nolint:err113
+		}
+
+		after[i] = rest[j+1:]
+		rest = rest[:j]
+	}
+
+	return append(append(fields[:free], rest), after...), nil
+}
+
+// Address returns the window address of the event, or empty if the event is
+// not related to a window.
+func (e Event) Address() hyprland.WindowAddress {
+	switch v := e.Data.(type) {
+	case ActiveWindowV2:
+		return v.Address
+	case OpenWindow:
+		return v.Address
+	case CloseWindow:
+		return v.Address
+	case MoveWindow:
+		return v.Address
+	case MoveWindowV2:
+		return v.Address
+	case ChangeFloatingMode:
+		return v.Address
+	case Urgent:
+		return v.Address
+	case WindowTitle:
+		return v.Address
+	case WindowTitleV2:
+		return v.Address
+	case Pin:
+		return v.Address
+	case Minimize:
+		return v.Address
+	case Bell:
+		return v.Address
+	case MoveIntogroup:
+		return v.Address
+	case MoveOutofGroup:
+		return v.Address
+	}
+
+	return ""
 }
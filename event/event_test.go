@@ -2,11 +2,17 @@ package event
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -92,6 +98,313 @@ func TestSubscribe(t *testing.T) {
 	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
 }
 
+// Returns a client connected to a fake connection, and the other side of the
+// connection to write events.
+func pipeClient(t *testing.T) (*EventClient, net.Conn) {
+	t.Helper()
+
+	server, client := net.Pipe()
+
+	t.Cleanup(func() {
+		server.Close()
+		client.Close()
+	})
+
+	return &EventClient{conn: client}, server
+}
+
+// Receive events until n events are received.
+func receiveN(t *testing.T, c *EventClient, n int) []ReceivedData {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var recv []ReceivedData
+
+	for len(recv) < n {
+		data, err := c.Receive(ctx)
+		assert.NoError(t, err)
+
+		recv = append(recv, data...)
+	}
+
+	return recv
+}
+
+func TestReceiveByteByByte(t *testing.T) {
+	c, server := pipeClient(t)
+
+	go func() {
+		for _, b := range []byte("workspace>>1\nactivewindow>>kitty,a, title\nworkspace>>2\n") {
+			server.Write([]byte{b})
+		}
+	}()
+
+	assert.DeepEqual(t, receiveN(t, c, 3), []ReceivedData{
+		{Type: EventWorkspace, Data: "1"},
+		{Type: EventActiveWindow, Data: "kitty,a, title"},
+		{Type: EventWorkspace, Data: "2"},
+	})
+}
+
+func TestReceiveBurst(t *testing.T) {
+	c, server := pipeClient(t)
+
+	const n = 10000
+
+	var (
+		burst bytes.Buffer
+		want  []ReceivedData
+	)
+
+	for i := 0; i < n; i++ {
+		data := RawData(fmt.Sprintf("%d,%d", i, i))
+		burst.WriteString(fmt.Sprintf("workspacev2>>%s\n", data))
+		want = append(want, ReceivedData{Type: EventWorkspaceV2, Data: data})
+	}
+
+	// A single line bigger than the read buffer, e.g.: a long title
+	title := strings.Repeat("title ", bufSize)
+	burst.WriteString(fmt.Sprintf("windowtitlev2>>80e62df0,%s\n", title))
+	want = append(want, ReceivedData{Type: EventWindowTitleV2, Data: RawData("80e62df0," + title)})
+
+	go server.Write(burst.Bytes())
+
+	assert.Greater(t, burst.Len(), bufSize)
+	assert.DeepEqual(t, receiveN(t, c, n+1), want)
+}
+
+func TestReceiveCancelPartialLine(t *testing.T) {
+	c, server := pipeClient(t)
+
+	go server.Write([]byte("workspace>>"))
+
+	// The partial line must be kept after the context is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Receive(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	go server.Write([]byte("1\n"))
+
+	assert.DeepEqual(t, receiveN(t, c, 1), []ReceivedData{
+		{Type: EventWorkspace, Data: "1"},
+	})
+}
+
+func TestEvents(t *testing.T) {
+	c, server := pipeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, errCh := c.Events(ctx, 10, EventOpenWindow, EventWorkspaceV2)
+
+	go server.Write([]byte(
+		"workspace>>1\nopenwindow>>80e62df0,1,kitty,a, b\nopenwindow>>malformed\nworkspacev2>>1,1\n",
+	))
+
+	var got []Event
+	for len(got) < 2 {
+		select {
+		case e := <-ch:
+			got = append(got, e)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout while waiting for events")
+		}
+	}
+
+	assert.DeepEqual(t, got, []Event{
+		{
+			Type: EventOpenWindow,
+			Data: OpenWindow{Address: "0x80e62df0", WorkspaceName: "1", Class: "kitty", Title: "a, b"},
+		},
+		{
+			Type: EventWorkspaceV2,
+			Data: WorkspaceV2{ID: 1, Name: "1"},
+		},
+	})
+
+	cancel()
+
+	err := <-errCh
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestEventsBlockedCancel(t *testing.T) {
+	c, server := pipeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Nobody is reading the unbuffered channel, but cancelling the
+	// context should still stop the goroutine
+	ch, errCh := c.Events(ctx, 0)
+
+	go server.Write([]byte("workspace>>1\nworkspace>>2\n"))
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	err := <-errCh
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	for range ch {
+		// Drain until closed
+	}
+}
+
+// Starts an event server that writes data to each new connection, closing
+// it afterwards if closeConn is true.
+func eventServer(t *testing.T, socket string, data string, closeConn bool) {
+	t.Helper()
+
+	l, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+
+	t.Cleanup(func() {
+		l.Close()
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+
+		var conns []net.Conn
+
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Write([]byte(data))
+
+			if closeConn {
+				conn.Close()
+			} else {
+				conns = append(conns, conn)
+			}
+		}
+	}()
+}
+
+type reconnectedHandler struct {
+	DefaultEventHandler
+	workspaces  []WorkspaceName
+	reconnected int
+}
+
+func (h *reconnectedHandler) Workspace(w WorkspaceName) { h.workspaces = append(h.workspaces, w) }
+func (h *reconnectedHandler) Reconnected()              { h.reconnected++ }
+
+func TestReconnect(t *testing.T) {
+	dir := t.TempDir()
+	oldSocket := filepath.Join(dir, "old.sock")
+	newSocket := filepath.Join(dir, "new.sock")
+
+	eventServer(t, oldSocket, "workspace>>1\n", true)
+	eventServer(t, newSocket, "workspace>>2\n", false)
+
+	c, err := NewClient(
+		oldSocket,
+		WithReconnect(time.Millisecond, 10*time.Millisecond),
+		// Simulate a new Hyprland instance
+		WithSocketResolver(func() (string, error) { return newSocket, nil }),
+	)
+	assert.NoError(t, err)
+
+	defer c.Close()
+
+	assert.Equal(t, c.State(), StateConnected)
+
+	h := &reconnectedHandler{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err = c.Subscribe(ctx, h, EventWorkspace)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.DeepEqual(t, h.workspaces, []WorkspaceName{"1", "2"})
+	assert.Equal(t, h.reconnected, 1)
+	assert.Equal(t, c.State(), StateConnected)
+
+	assert.NoError(t, c.Close())
+	assert.Equal(t, c.State(), StateClosed)
+}
+
+func TestReconnectRetry(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "event.sock")
+	eventServer(t, socket, "workspace>>1\n", true)
+
+	var attempts atomic.Int32
+
+	c, err := NewClient(
+		socket,
+		WithReconnect(time.Millisecond, 5*time.Millisecond),
+		WithSocketResolver(func() (string, error) {
+			attempts.Add(1)
+
+			return "", errors.New("not running")
+		}),
+	)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	data := receiveN(t, c, 1)
+	assert.DeepEqual(t, data, []ReceivedData{{Type: EventWorkspace, Data: "1"}})
+
+	_, err = c.Receive(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, c.State(), StateReconnecting)
+	assert.Greater(t, attempts.Load(), 1)
+
+	// Should keep trying to reconnect in the next call
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	previous := attempts.Load()
+	_, err = c.Receive(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Greater(t, attempts.Load(), previous)
+
+	// Close while reconnecting should stop the client
+	assert.NoError(t, c.Close())
+
+	_, err = c.Receive(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, c.State(), StateClosed)
+}
+
+func TestNoReconnect(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "event.sock")
+	eventServer(t, socket, "workspace>>1\n", true)
+
+	c, err := NewClient(socket)
+	assert.NoError(t, err)
+
+	defer c.Close()
+
+	receiveN(t, c, 1)
+
+	_, err = c.Receive(context.Background())
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
 func TestProcessEvent(t *testing.T) {
 	h := &FakeEventHandler{t: t}
 	c := &FakeEventClient{}
@@ -99,6 +412,245 @@ func TestProcessEvent(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		msg  ReceivedData
+		want any
+	}{
+		{
+			ReceivedData{Type: EventActiveWindow, Data: "kitty,vim a.go, b.go"},
+			ActiveWindow{Name: "kitty", Title: "vim a.go, b.go"},
+		},
+		{
+			ReceivedData{Type: EventOpenWindow, Data: "80e62df0,name:a,b,kitty,x, y"},
+			OpenWindow{Address: "0x80e62df0", WorkspaceName: "name:a", Class: "b", Title: "kitty,x, y"},
+		},
+		{
+			ReceivedData{Type: EventWindowTitleV2, Data: "80e62df0,a,b,c"},
+			WindowTitleV2{Address: "0x80e62df0", Title: "a,b,c"},
+		},
+		{
+			ReceivedData{Type: EventMoveWorkspace, Data: "a,b,DP-1"},
+			MoveWorkspace{WorkspaceName: "a,b", MonitorName: "DP-1"},
+		},
+		{
+			ReceivedData{Type: EventMoveWorkspaceV2, Data: "1,a,b,DP-1"},
+			MoveWorkspaceV2{ID: 1, Name: "a,b", MonitorName: "DP-1"},
+		},
+		{
+			ReceivedData{Type: EventActiveSpecialV2, Data: "-98,special:a,b,DP-1"},
+			ActiveSpecialV2{ID: -98, Name: "special:a,b", MonitorName: "DP-1"},
+		},
+		{
+			ReceivedData{Type: EventWorkspace, Data: "a,b"},
+			WorkspaceName("a,b"),
+		},
+		{
+			ReceivedData{Type: EventFullscreen, Data: "0"},
+			Fullscreen(false),
+		},
+		{
+			ReceivedData{Type: EventType("foo"), Data: "a,b"},
+			RawData("a,b"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.msg.Type), func(t *testing.T) {
+			e, err := ParseEvent(tt.msg)
+			assert.NoError(t, err)
+			assert.Equal(t, e.Type, tt.msg.Type)
+			assert.DeepEqual(t, e.Data, tt.want)
+		})
+	}
+}
+
+// Example of each event in AllEvents, and the expected parsed data.
+var allEventsData = map[EventType]struct {
+	data RawData
+	want any
+}{
+	EventWorkspace:          {"1", WorkspaceName("1")},
+	EventWorkspaceV2:        {"1,1", WorkspaceV2{ID: 1, Name: "1"}},
+	EventFocusedMonitor:     {"DP-1,1", FocusedMonitor{MonitorName: "DP-1", WorkspaceName: "1"}},
+	EventFocusedMonitorV2:   {"DP-1,1", FocusedMonitorV2{MonitorName: "DP-1", WorkspaceID: 1}},
+	EventActiveWindow:       {",", ActiveWindow{}},
+	EventActiveWindowV2:     {"80e62df0", ActiveWindowV2{Address: "0x80e62df0"}},
+	EventFullscreen:         {"1", Fullscreen(true)},
+	EventMonitorRemoved:     {"DP-1", MonitorName("DP-1")},
+	EventMonitorRemovedV2:   {"1,DP-1,Dell U2720Q, 27in", MonitorRemovedV2{ID: 1, Name: "DP-1", Description: "Dell U2720Q, 27in"}},
+	EventMonitorAdded:       {"DP-1", MonitorName("DP-1")},
+	EventMonitorAddedV2:     {"1,DP-1,Dell U2720Q, 27in", MonitorAddedV2{ID: 1, Name: "DP-1", Description: "Dell U2720Q, 27in"}},
+	EventCreateWorkspace:    {"1", WorkspaceName("1")},
+	EventCreateWorkspaceV2:  {"1,1", CreateWorkspaceV2{ID: 1, Name: "1"}},
+	EventDestroyWorkspace:   {"1", WorkspaceName("1")},
+	EventDestroyWorkspaceV2: {"1,1", DestroyWorkspaceV2{ID: 1, Name: "1"}},
+	EventMoveWorkspace:      {"1,DP-1", MoveWorkspace{WorkspaceName: "1", MonitorName: "DP-1"}},
+	EventMoveWorkspaceV2:    {"1,1,DP-1", MoveWorkspaceV2{ID: 1, Name: "1", MonitorName: "DP-1"}},
+	EventRenameWorkspace:    {"1,web, 2", RenameWorkspace{ID: 1, NewName: "web, 2"}},
+	EventActiveSpecial:      {",DP-1", ActiveSpecial{MonitorName: "DP-1"}},
+	EventActiveSpecialV2:    {",,DP-1", ActiveSpecialV2{MonitorName: "DP-1"}},
+	EventActiveLayout:       {"keyboard,English (US)", ActiveLayout{Type: "keyboard", Name: "English (US)"}},
+	EventOpenWindow:         {"80e62df0,1,kitty,a, b", OpenWindow{Address: "0x80e62df0", WorkspaceName: "1", Class: "kitty", Title: "a, b"}},
+	EventCloseWindow:        {"80e62df0", CloseWindow{Address: "0x80e62df0"}},
+	EventMoveWindow:         {"80e62df0,1", MoveWindow{Address: "0x80e62df0", WorkspaceName: "1"}},
+	EventMoveWindowV2:       {"80e62df0,1,1", MoveWindowV2{Address: "0x80e62df0", WorkspaceID: 1, WorkspaceName: "1"}},
+	EventOpenLayer:          {"wofi", OpenLayer("wofi")},
+	EventCloseLayer:         {"wofi", CloseLayer("wofi")},
+	EventSubMap:             {"", SubMap("")},
+	EventChangeFloatingMode: {"80e62df0,1", ChangeFloatingMode{Address: "0x80e62df0", Floating: true}},
+	EventUrgent:             {"80e62df0", Urgent{Address: "0x80e62df0"}},
+	EventScreencast:         {"1,1", Screencast{Sharing: true, Owner: ScreencastOwnerWindow}},
+	EventWindowTitle:        {"80e62df0", WindowTitle{Address: "0x80e62df0"}},
+	EventWindowTitleV2:      {"80e62df0,a, b", WindowTitleV2{Address: "0x80e62df0", Title: "a, b"}},
+	EventToggleGroup:        {"1,80e62df0,80e62e00", ToggleGroup{Toggle: true, Addresses: []hyprland.WindowAddress{"0x80e62df0", "0x80e62e00"}}},
+	EventMoveIntogroup:      {"80e62df0", MoveIntogroup{Address: "0x80e62df0"}},
+	EventMoveOutofGroup:     {"80e62df0", MoveOutofGroup{Address: "0x80e62df0"}},
+	EventIgnoreGroupLock:    {"1", IgnoreGroupLock(true)},
+	EventLockGroups:         {"0", LockGroups(false)},
+	EventConfigReloaded:     {"", nil},
+	EventPin:                {"80e62df0,1", Pin{Address: "0x80e62df0", Pinned: true}},
+	EventMinimize:           {"80e62df0,0", Minimize{Address: "0x80e62df0", Minimized: false}},
+	EventBell:               {"80e62df0", Bell{Address: "0x80e62df0"}},
+	EventCustom:             {"a>>b, c", Custom("a>>b, c")},
+}
+
+// Records the data received by each method.
+type recordingHandler struct {
+	got any
+}
+
+func (h *recordingHandler) Workspace(w WorkspaceName)               { h.got = w }
+func (h *recordingHandler) FocusedMonitor(m FocusedMonitor)         { h.got = m }
+func (h *recordingHandler) ActiveWindow(w ActiveWindow)             { h.got = w }
+func (h *recordingHandler) ActiveWindowV2(w ActiveWindowV2)         { h.got = w }
+func (h *recordingHandler) Fullscreen(f Fullscreen)                 { h.got = f }
+func (h *recordingHandler) MonitorRemoved(m MonitorName)            { h.got = m }
+func (h *recordingHandler) MonitorAdded(m MonitorName)              { h.got = m }
+func (h *recordingHandler) CreateWorkspace(w WorkspaceName)         { h.got = w }
+func (h *recordingHandler) DestroyWorkspace(w WorkspaceName)        { h.got = w }
+func (h *recordingHandler) MoveWorkspace(w MoveWorkspace)           { h.got = w }
+func (h *recordingHandler) ActiveLayout(l ActiveLayout)             { h.got = l }
+func (h *recordingHandler) OpenWindow(o OpenWindow)                 { h.got = o }
+func (h *recordingHandler) CloseWindow(c CloseWindow)               { h.got = c }
+func (h *recordingHandler) MoveWindow(m MoveWindow)                 { h.got = m }
+func (h *recordingHandler) OpenLayer(l OpenLayer)                   { h.got = l }
+func (h *recordingHandler) CloseLayer(c CloseLayer)                 { h.got = c }
+func (h *recordingHandler) SubMap(s SubMap)                         { h.got = s }
+func (h *recordingHandler) Screencast(s Screencast)                 { h.got = s }
+func (h *recordingHandler) ToggleGroup(t ToggleGroup)               { h.got = t }
+func (h *recordingHandler) MoveIntogroup(m MoveIntogroup)           { h.got = m }
+func (h *recordingHandler) MoveOutofGroup(m MoveOutofGroup)         { h.got = m }
+func (h *recordingHandler) IgnoreGroupLock(i IgnoreGroupLock)       { h.got = i }
+func (h *recordingHandler) LockGroups(l LockGroups)                 { h.got = l }
+func (h *recordingHandler) WorkspaceV2(w WorkspaceV2)               { h.got = w }
+func (h *recordingHandler) FocusedMonitorV2(m FocusedMonitorV2)     { h.got = m }
+func (h *recordingHandler) MonitorRemovedV2(m MonitorRemovedV2)     { h.got = m }
+func (h *recordingHandler) MonitorAddedV2(m MonitorAddedV2)         { h.got = m }
+func (h *recordingHandler) CreateWorkspaceV2(w CreateWorkspaceV2)   { h.got = w }
+func (h *recordingHandler) DestroyWorkspaceV2(w DestroyWorkspaceV2) { h.got = w }
+func (h *recordingHandler) MoveWorkspaceV2(w MoveWorkspaceV2)       { h.got = w }
+func (h *recordingHandler) RenameWorkspace(r RenameWorkspace)       { h.got = r }
+func (h *recordingHandler) ActiveSpecial(a ActiveSpecial)           { h.got = a }
+func (h *recordingHandler) ActiveSpecialV2(a ActiveSpecialV2)       { h.got = a }
+func (h *recordingHandler) MoveWindowV2(m MoveWindowV2)             { h.got = m }
+func (h *recordingHandler) ChangeFloatingMode(c ChangeFloatingMode) { h.got = c }
+func (h *recordingHandler) Urgent(u Urgent)                         { h.got = u }
+func (h *recordingHandler) WindowTitle(w WindowTitle)               { h.got = w }
+func (h *recordingHandler) WindowTitleV2(w WindowTitleV2)           { h.got = w }
+func (h *recordingHandler) ConfigReloaded()                         { h.got = "configreloaded" }
+func (h *recordingHandler) Pin(p Pin)                               { h.got = p }
+func (h *recordingHandler) Minimize(m Minimize)                     { h.got = m }
+func (h *recordingHandler) Bell(b Bell)                             { h.got = b }
+func (h *recordingHandler) Custom(c Custom)                         { h.got = c }
+func (h *recordingHandler) Unknown(e ReceivedData)                  { h.got = e }
+
+func TestAllEvents(t *testing.T) {
+	for _, event := range AllEvents {
+		t.Run(string(event), func(t *testing.T) {
+			tt, ok := allEventsData[event]
+			if !ok {
+				t.Fatalf("missing example for event %s", event)
+			}
+
+			msg := ReceivedData{Type: event, Data: tt.data}
+
+			// Must survive the round-trip through the socket
+			c, server := pipeClient(t)
+			go server.Write([]byte(fmt.Sprintf("%s>>%s\n", msg.Type, msg.Data)))
+			assert.DeepEqual(t, receiveN(t, c, 1), []ReceivedData{msg})
+
+			e, err := ParseEvent(msg)
+			assert.NoError(t, err)
+			assert.DeepEqual(t, e.Data, tt.want)
+
+			h := &recordingHandler{}
+			processEvent(h, msg, AllEvents)
+
+			if event == EventConfigReloaded {
+				assert.DeepEqual(t, h.got, any("configreloaded"))
+			} else {
+				assert.DeepEqual(t, h.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownEventHandler(t *testing.T) {
+	h := &recordingHandler{}
+	msg := ReceivedData{Type: "foo", Data: "a,b"}
+
+	// Unknown events are not filtered, since they can not be subscribed
+	processEvent(h, msg, []EventType{EventWorkspace})
+	assert.DeepEqual(t, h.got, any(msg))
+
+	h.got = nil
+	processEvent(h, ReceivedData{Type: EventOpenLayer, Data: "wofi"}, []EventType{EventWorkspace})
+	assert.DeepEqual(t, h.got, nil)
+
+	// Handlers without Unknown are not affected
+	processEvent(&DefaultEventHandler{}, msg, AllEvents)
+}
+
+func TestParseEventError(t *testing.T) {
+	tests := []ReceivedData{
+		{Type: EventOpenWindow, Data: "80e62df0,1,kitty"},
+		{Type: EventMoveWorkspaceV2, Data: "1,DP-1"},
+		{Type: EventActiveSpecial, Data: "special"},
+		{Type: EventPin, Data: "80e62df0"},
+		{Type: EventPin, Data: "80e62df0,true"},
+		{Type: EventFullscreen, Data: "2"},
+		{Type: EventUrgent, Data: "xyz"},
+		{Type: EventToggleGroup, Data: "1,80e62df0,xyz"},
+		{Type: EventWorkspaceV2, Data: "name:a,a"},
+		{Type: EventMonitorAddedV2, Data: "a,DP-1,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.Type), func(t *testing.T) {
+			_, err := ParseEvent(tt)
+
+			var parseErr *ParseError
+			assert.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, parseErr.Event, tt)
+		})
+	}
+}
+
+func FuzzParseEvent(f *testing.F) {
+	for _, data := range []string{"", ",", ",,,", "a", "1,a,b"} {
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data string) {
+		// Must never panic
+		for _, event := range AllEvents {
+			ParseEvent(ReceivedData{Type: event, Data: RawData(data)})
+			processEvent(&DefaultEventHandler{}, ReceivedData{Type: event, Data: RawData(data)}, AllEvents)
+		}
+	})
+}
+
 func (f *FakeEventClient) Receive(context.Context) ([]ReceivedData, error) {
 	return []ReceivedData{
 		{
@@ -223,18 +775,18 @@ func (h *FakeEventHandler) ActiveLayout(l ActiveLayout) {
 }
 
 func (h *FakeEventHandler) OpenWindow(o OpenWindow) {
-	assert.Equal(h.t, o.Address, "80e62df0")
+	assert.Equal(h.t, o.Address, "0x80e62df0")
 	assert.Equal(h.t, o.Class, "jetbrains-goland")
 	assert.Equal(h.t, o.Title, "win430")
 	assert.Equal(h.t, o.WorkspaceName, "2")
 }
 
 func (h *FakeEventHandler) CloseWindow(c CloseWindow) {
-	assert.Equal(h.t, c.Address, "80e62df0")
+	assert.Equal(h.t, c.Address, "0x80e62df0")
 }
 
 func (h *FakeEventHandler) MoveWindow(m MoveWindow) {
-	assert.Equal(h.t, m.Address, "80e62df0")
+	assert.Equal(h.t, m.Address, "0x80e62df0")
 	assert.Equal(h.t, m.WorkspaceName, "1")
 }
 
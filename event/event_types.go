@@ -2,12 +2,60 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
 )
 
 // EventClient is the event struct from hyprland-go.
 type EventClient struct {
+	mu   sync.Mutex
 	conn net.Conn
+	// Partial line from the previous read
+	pending []byte
+	state   atomic.Int32
+
+	reconnect          bool
+	minDelay, maxDelay time.Duration
+	resolve            func() (string, error)
+}
+
+// ConnState is the state of the [EventClient] connection.
+type ConnState int32
+
+const (
+	// Connected and receiving events.
+	StateConnected ConnState = iota
+	// The connection was lost and the client is trying to reconnect, see
+	// [WithReconnect].
+	StateReconnecting
+	// The client was closed.
+	StateClosed
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	}
+
+	return fmt.Sprintf("ConnState(%d)", int32(s))
+}
+
+// ReconnectedHandler can be optionally implemented by an [EventHandler] to
+// be notified when the client reconnects, see [WithReconnect].
+type ReconnectedHandler interface {
+	// Reconnected emitted after the client reconnects. Events emitted
+	// while disconnected are lost, so any state should be refreshed.
+	Reconnected()
 }
 
 // Event Client interface, right now only used for testing.
@@ -24,6 +72,34 @@ type ReceivedData struct {
 	Data RawData
 }
 
+// Event is a parsed event, see [ParseEvent].
+type Event struct {
+	Type EventType
+	// Data has the same type received by the respective [EventHandler]
+	// method, e.g.: [OpenWindow] for [EventOpenWindow].
+	Data any
+}
+
+// ParseError is returned when an event is malformed, e.g.: has less fields
+// than expected.
+type ParseError struct {
+	Event  ReceivedData
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("malformed event '%s>>%s': %s", e.Event.Type, e.Event.Data, e.Reason)
+}
+
+// UnknownEventHandler can be implemented by an [EventHandler] to receive
+// events that are not part of [AllEvents], e.g.: events added in newer
+// Hyprland versions, so they are not silently dropped.
+type UnknownEventHandler interface {
+	// Unknown emitted for every event with an unknown type, regardless of
+	// the events passed to [EventClient.Subscribe].
+	Unknown(e ReceivedData)
+}
+
 // EventHandler is the interface that defines all methods to handle each of
 // events emitted by Hyprland.
 // You can find move information about each event in the main Hyprland Wiki:
@@ -34,9 +110,14 @@ type EventHandler interface {
 	Workspace(w WorkspaceName)
 	// FocusedMonitor emitted on the active monitor being changed.
 	FocusedMonitor(m FocusedMonitor)
-	// ActiveWindow emitted on the active window being changed.
+	// ActiveWindow emitted on the active window being changed. Both fields
+	// are empty if there is no active window.
 	ActiveWindow(w ActiveWindow)
-	// Fullscreen emitted when a fullscreen status of a window changes.
+	// ActiveWindowV2 emitted on the active window being changed, includes
+	// the window address. The address is empty if there is no active window.
+	ActiveWindowV2(w ActiveWindowV2)
+	// Fullscreen emitted when a fullscreen status of the active window
+	// changes. Hyprland does not include the window address in this event.
 	Fullscreen(f Fullscreen)
 	// MonitorRemoved emitted when a monitor is removed (disconnected).
 	MonitorRemoved(m MonitorName)
@@ -66,7 +147,8 @@ type EventHandler interface {
 	// Screencast is fired when the screencopy state of a client changes.
 	// Keep in mind there might be multiple separate clients.
 	Screencast(s Screencast)
-	// ToggleGroup emitted when a group is toggled.
+	// ToggleGroup emitted when a group is toggled, includes the addresses
+	// of all windows in the group.
 	ToggleGroup(t ToggleGroup)
 	// MoveIntogroup emitted when a window is moved into a group.
 	MoveIntogroup(m MoveIntogroup)
@@ -93,8 +175,10 @@ type EventHandler interface {
 	// RenameWorkspace emitted when a workspace is renamed.
 	RenameWorkspace(r RenameWorkspace)
 	// ActiveSpecial emitted when special workspace opens/closes on a monitor.
+	// The workspace name is empty when it closes.
 	ActiveSpecial(a ActiveSpecial)
 	// ActiveSpecialV2 emitted when special workspace opens/closes, includes ID.
+	// The workspace ID and name are empty when it closes.
 	ActiveSpecialV2(a ActiveSpecialV2)
 	// MoveWindowV2 emitted when window moves to workspace, includes workspace ID.
 	MoveWindowV2(m MoveWindowV2)
@@ -114,6 +198,9 @@ type EventHandler interface {
 	Minimize(m Minimize)
 	// Bell emitted when an app requests the system bell.
 	Bell(b Bell)
+	// Custom emitted by 'hyprctl dispatch event DATA', useful for
+	// communication between scripts.
+	Custom(c Custom)
 }
 
 const (
@@ -157,8 +244,12 @@ const (
 	EventWindowTitleV2      EventType = "windowtitlev2"
 	EventConfigReloaded     EventType = "configreloaded"
 	EventPin                EventType = "pin"
-	EventMinimize           EventType = "minimize"
+	EventMinimize           EventType = "minimized"
 	EventBell               EventType = "bell"
+	EventCustom             EventType = "custom"
+	// Synthetic event received after reconnecting, see [WithReconnect].
+	// It is not emitted by Hyprland and it is not part of [AllEvents].
+	EventReconnected EventType = "hyprland-go-reconnected"
 )
 
 // AllEvents is the combination of all event types, useful if you want to
@@ -208,6 +299,7 @@ var AllEvents = []EventType{
 	EventPin,
 	EventMinimize,
 	EventBell,
+	EventCustom,
 }
 
 type MoveWorkspace struct {
@@ -233,16 +325,17 @@ type CloseLayer string
 type OpenLayer string
 
 type MoveWindow struct {
-	Address string
+	Address hyprland.WindowAddress
 	WorkspaceName
 }
 
 type CloseWindow struct {
-	Address string
+	Address hyprland.WindowAddress
 }
 
 type OpenWindow struct {
-	Address, Class, Title string
+	Address      hyprland.WindowAddress
+	Class, Title string
 	WorkspaceName
 }
 
@@ -254,69 +347,83 @@ type ActiveWindow struct {
 	Name, Title string
 }
 
+type ActiveWindowV2 struct {
+	Address hyprland.WindowAddress
+}
+
 type ActiveWorkspace WorkspaceName
 
+// ScreencastOwner is what is being shared in a [Screencast].
+type ScreencastOwner string
+
+const (
+	ScreencastOwnerMonitor ScreencastOwner = "0"
+	ScreencastOwnerWindow  ScreencastOwner = "1"
+)
+
 type Screencast struct {
 	// True if a screen or window is being shared.
 	Sharing bool
 
-	// "0" if monitor is shared, "1" if window is shared.
-	Owner string
+	// [ScreencastOwnerMonitor] if monitor is shared,
+	// [ScreencastOwnerWindow] if window is shared.
+	Owner ScreencastOwner
 }
 
 type ToggleGroup struct {
-	Toggle  bool
-	Address string
+	// True if the group was created, false if it was destroyed.
+	Toggle    bool
+	Addresses []hyprland.WindowAddress
 }
 
 type MoveIntogroup struct {
-	Address string
+	Address hyprland.WindowAddress
 }
 
 type MoveOutofGroup struct {
-	Address string
+	Address hyprland.WindowAddress
 }
 
 type WorkspaceV2 struct {
-	ID   string
+	ID   hyprland.WorkspaceID
 	Name WorkspaceName
 }
 
 type FocusedMonitorV2 struct {
 	MonitorName
-	WorkspaceID string
+	WorkspaceID hyprland.WorkspaceID
 }
 
 type MonitorRemovedV2 struct {
-	ID          string
+	ID          hyprland.MonitorID
 	Name        MonitorName
 	Description string
 }
 
 type MonitorAddedV2 struct {
-	ID          string
+	ID          hyprland.MonitorID
 	Name        MonitorName
 	Description string
 }
 
 type CreateWorkspaceV2 struct {
-	ID   string
+	ID   hyprland.WorkspaceID
 	Name WorkspaceName
 }
 
 type DestroyWorkspaceV2 struct {
-	ID   string
+	ID   hyprland.WorkspaceID
 	Name WorkspaceName
 }
 
 type MoveWorkspaceV2 struct {
-	ID   string
+	ID   hyprland.WorkspaceID
 	Name WorkspaceName
 	MonitorName
 }
 
 type RenameWorkspace struct {
-	ID      string
+	ID      hyprland.WorkspaceID
 	NewName WorkspaceName
 }
 
@@ -326,49 +433,51 @@ type ActiveSpecial struct {
 }
 
 type ActiveSpecialV2 struct {
-	ID   string
+	ID   hyprland.WorkspaceID
 	Name WorkspaceName
 	MonitorName
 }
 
 type MoveWindowV2 struct {
-	Address     string
-	WorkspaceID string
+	Address     hyprland.WindowAddress
+	WorkspaceID hyprland.WorkspaceID
 	WorkspaceName
 }
 
 type ChangeFloatingMode struct {
-	Address  string
+	Address  hyprland.WindowAddress
 	Floating bool
 }
 
 type Urgent struct {
-	Address string
+	Address hyprland.WindowAddress
 }
 
 type WindowTitle struct {
-	Address string
+	Address hyprland.WindowAddress
 }
 
 type WindowTitleV2 struct {
-	Address string
+	Address hyprland.WindowAddress
 	Title   string
 }
 
 type Pin struct {
-	Address string
+	Address hyprland.WindowAddress
 	Pinned  bool
 }
 
 type Minimize struct {
-	Address   string
+	Address   hyprland.WindowAddress
 	Minimized bool
 }
 
 type Bell struct {
-	Address string
+	Address hyprland.WindowAddress
 }
 
 type IgnoreGroupLock bool
 
 type LockGroups bool
+
+type Custom string
@@ -0,0 +1,387 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Key for listeners of unknown events, since events always have a type.
+const eventUnknown EventType = ""
+
+// Router dispatches events to functions registered per event type, as an
+// alternative to implementing the whole [EventHandler] interface, e.g.:
+//
+//	r := event.NewRouter()
+//	h := r.OnOpenWindow(func(w event.OpenWindow) { fmt.Println(w.Title) })
+//	defer h.Off()
+//	err := r.Run(ctx, event.MustClient())
+//
+// Multiple functions can be registered for the same event, and they are
+// called in the order they were registered. Registering and unregistering
+// functions is safe while the router is running.
+type Router struct {
+	mu        sync.Mutex
+	nextID    uint64
+	listeners map[EventType][]listener
+}
+
+type listener struct {
+	id uint64
+	f  func(e Event)
+}
+
+// Handle is returned when registering functions in a [Router], and can be
+// used to unregister them.
+type Handle struct {
+	r   *Router
+	ids map[EventType]uint64
+}
+
+// NewRouter creates a new empty [Router].
+func NewRouter() *Router {
+	return &Router{listeners: map[EventType][]listener{}}
+}
+
+// On registers f to be called for each event of type t, including unknown
+// events, where the data will be a [RawData].
+func (r *Router) On(t EventType, f func(e Event)) Handle {
+	return r.on(map[EventType]func(Event){t: f})
+}
+
+// OnUnknown registers f to be called for each event that is not part of
+// [AllEvents], where the data will be a [RawData].
+func (r *Router) OnUnknown(f func(e Event)) Handle {
+	return r.On(eventUnknown, f)
+}
+
+// AddHandler registers an [EventHandler] for the given events, allowing
+// existing handlers to be used together with other functions. If ev
+// implements [ReconnectedHandler] or [UnknownEventHandler], it is also
+// registered for [EventReconnected] or unknown events.
+func (r *Router) AddHandler(ev EventHandler, events ...EventType) Handle {
+	fs := map[EventType]func(Event){}
+
+	for _, t := range events {
+		fs[t] = func(e Event) { dispatchEvent(ev, e) }
+	}
+
+	if _, ok := ev.(ReconnectedHandler); ok {
+		fs[EventReconnected] = func(e Event) { dispatchEvent(ev, e) }
+	}
+
+	if u, ok := ev.(UnknownEventHandler); ok {
+		fs[eventUnknown] = func(e Event) {
+			data, _ := e.Data.(RawData)
+			u.Unknown(ReceivedData{Type: e.Type, Data: data})
+		}
+	}
+
+	return r.on(fs)
+}
+
+func (r *Router) on(fs map[EventType]func(Event)) Handle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h := Handle{r: r, ids: map[EventType]uint64{}}
+
+	for t, f := range fs {
+		r.nextID++
+		r.listeners[t] = append(r.listeners[t], listener{id: r.nextID, f: f})
+		h.ids[t] = r.nextID
+	}
+
+	return h
+}
+
+// Off unregisters the functions, that will not be called for new events.
+// Calling Off multiple times is safe.
+func (h Handle) Off() {
+	if h.r == nil {
+		return
+	}
+
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	for t, id := range h.ids {
+		ls := h.r.listeners[t]
+		for i, l := range ls {
+			if l.id == id {
+				// Create a new slice, since Dispatch may be
+				// iterating the old one
+				h.r.listeners[t] = append(ls[:i:i], ls[i+1:]...)
+
+				break
+			}
+		}
+	}
+}
+
+// Dispatch calls all functions registered for the event type, and the ones
+// registered with [Router.OnUnknown] if the event type is unknown.
+func (r *Router) Dispatch(e Event) {
+	r.mu.Lock()
+	ls := r.listeners[e.Type]
+
+	if !slices.Contains(AllEvents, e.Type) && e.Type != EventReconnected {
+		ls = append(ls[:len(ls):len(ls)], r.listeners[eventUnknown]...)
+	}
+	r.mu.Unlock()
+
+	for _, l := range ls {
+		l.f(e)
+	}
+}
+
+// Run receives events from c and dispatch them until ctx is cancelled or an
+// error happens. Malformed events are skipped.
+func (r *Router) Run(ctx context.Context, c *EventClient) error {
+	return receiveEvents(ctx, c, r.Dispatch)
+}
+
+// Receives and parses events from c, calling f for each one.
+func receiveEvents(ctx context.Context, c *EventClient, f func(e Event)) error {
+	for {
+		msg, err := c.Receive(ctx)
+		if err != nil {
+			return fmt.Errorf("event processing: %w", err)
+		}
+
+		for _, data := range msg {
+			e, err := ParseEvent(data)
+			if err != nil {
+				continue
+			}
+
+			f(e)
+		}
+	}
+}
+
+// Registers a typed function for an event.
+func on[T any](r *Router, t EventType, f func(T)) Handle {
+	return r.On(t, func(e Event) { call(e.Data, f) })
+}
+
+// OnWorkspace registers f to be called for each [EventWorkspace].
+func (r *Router) OnWorkspace(f func(WorkspaceName)) Handle {
+	return on(r, EventWorkspace, f)
+}
+
+// OnFocusedMonitor registers f to be called for each [EventFocusedMonitor].
+func (r *Router) OnFocusedMonitor(f func(FocusedMonitor)) Handle {
+	return on(r, EventFocusedMonitor, f)
+}
+
+// OnActiveWindow registers f to be called for each [EventActiveWindow].
+func (r *Router) OnActiveWindow(f func(ActiveWindow)) Handle {
+	return on(r, EventActiveWindow, f)
+}
+
+// OnActiveWindowV2 registers f to be called for each [EventActiveWindowV2].
+func (r *Router) OnActiveWindowV2(f func(ActiveWindowV2)) Handle {
+	return on(r, EventActiveWindowV2, f)
+}
+
+// OnFullscreen registers f to be called for each [EventFullscreen].
+func (r *Router) OnFullscreen(f func(Fullscreen)) Handle {
+	return on(r, EventFullscreen, f)
+}
+
+// OnMonitorRemoved registers f to be called for each [EventMonitorRemoved].
+func (r *Router) OnMonitorRemoved(f func(MonitorName)) Handle {
+	return on(r, EventMonitorRemoved, f)
+}
+
+// OnMonitorAdded registers f to be called for each [EventMonitorAdded].
+func (r *Router) OnMonitorAdded(f func(MonitorName)) Handle {
+	return on(r, EventMonitorAdded, f)
+}
+
+// OnCreateWorkspace registers f to be called for each [EventCreateWorkspace].
+func (r *Router) OnCreateWorkspace(f func(WorkspaceName)) Handle {
+	return on(r, EventCreateWorkspace, f)
+}
+
+// OnDestroyWorkspace registers f to be called for each [EventDestroyWorkspace].
+func (r *Router) OnDestroyWorkspace(f func(WorkspaceName)) Handle {
+	return on(r, EventDestroyWorkspace, f)
+}
+
+// OnMoveWorkspace registers f to be called for each [EventMoveWorkspace].
+func (r *Router) OnMoveWorkspace(f func(MoveWorkspace)) Handle {
+	return on(r, EventMoveWorkspace, f)
+}
+
+// OnActiveLayout registers f to be called for each [EventActiveLayout].
+func (r *Router) OnActiveLayout(f func(ActiveLayout)) Handle {
+	return on(r, EventActiveLayout, f)
+}
+
+// OnOpenWindow registers f to be called for each [EventOpenWindow].
+func (r *Router) OnOpenWindow(f func(OpenWindow)) Handle {
+	return on(r, EventOpenWindow, f)
+}
+
+// OnCloseWindow registers f to be called for each [EventCloseWindow].
+func (r *Router) OnCloseWindow(f func(CloseWindow)) Handle {
+	return on(r, EventCloseWindow, f)
+}
+
+// OnMoveWindow registers f to be called for each [EventMoveWindow].
+func (r *Router) OnMoveWindow(f func(MoveWindow)) Handle {
+	return on(r, EventMoveWindow, f)
+}
+
+// OnOpenLayer registers f to be called for each [EventOpenLayer].
+func (r *Router) OnOpenLayer(f func(OpenLayer)) Handle {
+	return on(r, EventOpenLayer, f)
+}
+
+// OnCloseLayer registers f to be called for each [EventCloseLayer].
+func (r *Router) OnCloseLayer(f func(CloseLayer)) Handle {
+	return on(r, EventCloseLayer, f)
+}
+
+// OnSubMap registers f to be called for each [EventSubMap].
+func (r *Router) OnSubMap(f func(SubMap)) Handle {
+	return on(r, EventSubMap, f)
+}
+
+// OnScreencast registers f to be called for each [EventScreencast].
+func (r *Router) OnScreencast(f func(Screencast)) Handle {
+	return on(r, EventScreencast, f)
+}
+
+// OnToggleGroup registers f to be called for each [EventToggleGroup].
+func (r *Router) OnToggleGroup(f func(ToggleGroup)) Handle {
+	return on(r, EventToggleGroup, f)
+}
+
+// OnMoveIntogroup registers f to be called for each [EventMoveIntogroup].
+func (r *Router) OnMoveIntogroup(f func(MoveIntogroup)) Handle {
+	return on(r, EventMoveIntogroup, f)
+}
+
+// OnMoveOutofGroup registers f to be called for each [EventMoveOutofGroup].
+func (r *Router) OnMoveOutofGroup(f func(MoveOutofGroup)) Handle {
+	return on(r, EventMoveOutofGroup, f)
+}
+
+// OnIgnoreGroupLock registers f to be called for each [EventIgnoreGroupLock].
+func (r *Router) OnIgnoreGroupLock(f func(IgnoreGroupLock)) Handle {
+	return on(r, EventIgnoreGroupLock, f)
+}
+
+// OnLockGroups registers f to be called for each [EventLockGroups].
+func (r *Router) OnLockGroups(f func(LockGroups)) Handle {
+	return on(r, EventLockGroups, f)
+}
+
+// OnWorkspaceV2 registers f to be called for each [EventWorkspaceV2].
+func (r *Router) OnWorkspaceV2(f func(WorkspaceV2)) Handle {
+	return on(r, EventWorkspaceV2, f)
+}
+
+// OnFocusedMonitorV2 registers f to be called for each [EventFocusedMonitorV2].
+func (r *Router) OnFocusedMonitorV2(f func(FocusedMonitorV2)) Handle {
+	return on(r, EventFocusedMonitorV2, f)
+}
+
+// OnMonitorRemovedV2 registers f to be called for each [EventMonitorRemovedV2].
+func (r *Router) OnMonitorRemovedV2(f func(MonitorRemovedV2)) Handle {
+	return on(r, EventMonitorRemovedV2, f)
+}
+
+// OnMonitorAddedV2 registers f to be called for each [EventMonitorAddedV2].
+func (r *Router) OnMonitorAddedV2(f func(MonitorAddedV2)) Handle {
+	return on(r, EventMonitorAddedV2, f)
+}
+
+// OnCreateWorkspaceV2 registers f to be called for each [EventCreateWorkspaceV2].
+func (r *Router) OnCreateWorkspaceV2(f func(CreateWorkspaceV2)) Handle {
+	return on(r, EventCreateWorkspaceV2, f)
+}
+
+// OnDestroyWorkspaceV2 registers f to be called for each [EventDestroyWorkspaceV2].
+func (r *Router) OnDestroyWorkspaceV2(f func(DestroyWorkspaceV2)) Handle {
+	return on(r, EventDestroyWorkspaceV2, f)
+}
+
+// OnMoveWorkspaceV2 registers f to be called for each [EventMoveWorkspaceV2].
+func (r *Router) OnMoveWorkspaceV2(f func(MoveWorkspaceV2)) Handle {
+	return on(r, EventMoveWorkspaceV2, f)
+}
+
+// OnRenameWorkspace registers f to be called for each [EventRenameWorkspace].
+func (r *Router) OnRenameWorkspace(f func(RenameWorkspace)) Handle {
+	return on(r, EventRenameWorkspace, f)
+}
+
+// OnActiveSpecial registers f to be called for each [EventActiveSpecial].
+func (r *Router) OnActiveSpecial(f func(ActiveSpecial)) Handle {
+	return on(r, EventActiveSpecial, f)
+}
+
+// OnActiveSpecialV2 registers f to be called for each [EventActiveSpecialV2].
+func (r *Router) OnActiveSpecialV2(f func(ActiveSpecialV2)) Handle {
+	return on(r, EventActiveSpecialV2, f)
+}
+
+// OnMoveWindowV2 registers f to be called for each [EventMoveWindowV2].
+func (r *Router) OnMoveWindowV2(f func(MoveWindowV2)) Handle {
+	return on(r, EventMoveWindowV2, f)
+}
+
+// OnChangeFloatingMode registers f to be called for each [EventChangeFloatingMode].
+func (r *Router) OnChangeFloatingMode(f func(ChangeFloatingMode)) Handle {
+	return on(r, EventChangeFloatingMode, f)
+}
+
+// OnUrgent registers f to be called for each [EventUrgent].
+func (r *Router) OnUrgent(f func(Urgent)) Handle {
+	return on(r, EventUrgent, f)
+}
+
+// OnWindowTitle registers f to be called for each [EventWindowTitle].
+func (r *Router) OnWindowTitle(f func(WindowTitle)) Handle {
+	return on(r, EventWindowTitle, f)
+}
+
+// OnWindowTitleV2 registers f to be called for each [EventWindowTitleV2].
+func (r *Router) OnWindowTitleV2(f func(WindowTitleV2)) Handle {
+	return on(r, EventWindowTitleV2, f)
+}
+
+// OnPin registers f to be called for each [EventPin].
+func (r *Router) OnPin(f func(Pin)) Handle {
+	return on(r, EventPin, f)
+}
+
+// OnMinimize registers f to be called for each [EventMinimize].
+func (r *Router) OnMinimize(f func(Minimize)) Handle {
+	return on(r, EventMinimize, f)
+}
+
+// OnBell registers f to be called for each [EventBell].
+func (r *Router) OnBell(f func(Bell)) Handle {
+	return on(r, EventBell, f)
+}
+
+// OnCustom registers f to be called for each [EventCustom].
+func (r *Router) OnCustom(f func(Custom)) Handle {
+	return on(r, EventCustom, f)
+}
+
+// OnConfigReloaded registers f to be called for each [EventConfigReloaded].
+func (r *Router) OnConfigReloaded(f func()) Handle {
+	return r.On(EventConfigReloaded, func(Event) { f() })
+}
+
+// OnReconnected registers f to be called for each [EventReconnected].
+func (r *Router) OnReconnected(f func()) Handle {
+	return r.On(EventReconnected, func(Event) { f() })
+}
@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestRouter(t *testing.T) {
+	r := NewRouter()
+
+	var got []string
+
+	h1 := r.OnOpenWindow(func(w OpenWindow) { got = append(got, "1:"+w.Title) })
+	r.OnOpenWindow(func(w OpenWindow) { got = append(got, "2:"+w.Title) })
+	r.OnConfigReloaded(func() { got = append(got, "reloaded") })
+	r.On(EventType("foo"), func(e Event) {
+		data, _ := e.Data.(RawData)
+		got = append(got, "foo:"+string(data))
+	})
+
+	r.Dispatch(Event{Type: EventOpenWindow, Data: OpenWindow{Title: "a"}})
+	r.Dispatch(Event{Type: EventConfigReloaded})
+	r.Dispatch(Event{Type: EventType("foo"), Data: RawData("b")})
+	r.Dispatch(Event{Type: EventCloseWindow, Data: CloseWindow{}})
+
+	h1.Off()
+	h1.Off()
+	r.Dispatch(Event{Type: EventOpenWindow, Data: OpenWindow{Title: "c"}})
+
+	assert.DeepEqual(t, got, []string{"1:a", "2:a", "reloaded", "foo:b", "2:c"})
+}
+
+func TestRouterAddHandler(t *testing.T) {
+	r := NewRouter()
+	h := &reconnectedHandler{}
+
+	var workspaces []WorkspaceName
+
+	handle := r.AddHandler(h, EventWorkspace)
+	r.OnWorkspace(func(w WorkspaceName) { workspaces = append(workspaces, w) })
+
+	r.Dispatch(Event{Type: EventWorkspace, Data: WorkspaceName("1")})
+	r.Dispatch(Event{Type: EventReconnected})
+
+	handle.Off()
+	r.Dispatch(Event{Type: EventWorkspace, Data: WorkspaceName("2")})
+	r.Dispatch(Event{Type: EventReconnected})
+
+	assert.DeepEqual(t, h.workspaces, []WorkspaceName{"1"})
+	assert.Equal(t, h.reconnected, 1)
+	assert.DeepEqual(t, workspaces, []WorkspaceName{"1", "2"})
+}
+
+func TestRouterUnknown(t *testing.T) {
+	r := NewRouter()
+	h := &recordingHandler{}
+
+	var got []EventType
+
+	r.OnUnknown(func(e Event) { got = append(got, e.Type) })
+	r.AddHandler(h, EventWorkspace)
+
+	r.Dispatch(Event{Type: EventWorkspace, Data: WorkspaceName("1")})
+	r.Dispatch(Event{Type: EventReconnected})
+	r.Dispatch(Event{Type: EventType("foo"), Data: RawData("a")})
+
+	assert.DeepEqual(t, got, []EventType{"foo"})
+	assert.DeepEqual(t, h.got, any(ReceivedData{Type: "foo", Data: "a"}))
+}
+
+func TestRouterRun(t *testing.T) {
+	c, server := pipeClient(t)
+	r := NewRouter()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	titles := make(chan string, 10)
+	r.OnWindowTitleV2(func(w WindowTitleV2) { titles <- w.Title })
+
+	var handle Handle
+	handle = r.OnWindowTitleV2(func(WindowTitleV2) {
+		// Unregistering from inside a listener must not deadlock
+		handle.Off()
+	})
+
+	errCh := make(chan error, 1)
+
+	go func() { errCh <- r.Run(ctx, c) }()
+
+	go server.Write([]byte("windowtitlev2>>80e62df0,a, b\nwindowtitlev2>>malformed\nworkspace>>1\n"))
+
+	select {
+	case title := <-titles:
+		assert.Equal(t, title, "a, b")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout while waiting for events")
+	}
+
+	cancel()
+
+	assert.True(t, errors.Is(<-errCh, context.Canceled))
+}
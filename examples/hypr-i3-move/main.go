@@ -27,7 +27,7 @@ func main() {
 		os.Exit(1)
 	}
 	mode := os.Args[1]
-	direction := os.Args[2]
+	direction := hyprland.Direction(os.Args[2])
 	client := hyprland.MustClient()
 
 	aWindow := must1(client.ActiveWindow())
@@ -37,22 +37,22 @@ func main() {
 	switch mode {
 	case "focus":
 		if len(grouped) == 0 {
-			client.Dispatch(fmt.Sprintf("movefocus %s", direction))
+			client.DispatchAll(hyprland.MoveFocus(direction))
 			return
 		}
 
 		switch direction {
-		case "l", "u":
+		case hyprland.DirectionLeft, hyprland.DirectionUp:
 			if addr == grouped[0] {
-				client.Dispatch(fmt.Sprintf("movefocus %s", direction))
+				client.DispatchAll(hyprland.MoveFocus(direction))
 			} else {
-				client.Dispatch("changegroupactive b")
+				client.DispatchAll(hyprland.ChangeGroupActive(false))
 			}
-		case "r", "d":
+		case hyprland.DirectionRight, hyprland.DirectionDown:
 			if addr == grouped[len(grouped)-1] {
-				client.Dispatch(fmt.Sprintf("movefocus %s", direction))
+				client.DispatchAll(hyprland.MoveFocus(direction))
 			} else {
-				client.Dispatch("changegroupactive f")
+				client.DispatchAll(hyprland.ChangeGroupActive(true))
 			}
 		default:
 			log.Printf("Unknown direction '%s'. Valid options are: l, r, u, d.", direction)
@@ -60,21 +60,21 @@ func main() {
 		}
 	case "move":
 		if len(grouped) == 0 {
-			client.Dispatch(fmt.Sprintf("movewindoworgroup %s", direction))
+			client.DispatchAll(hyprland.MoveWindowOrGroup(direction))
 			return
 		}
 		switch direction {
-		case "l", "u":
+		case hyprland.DirectionLeft, hyprland.DirectionUp:
 			if addr == grouped[0] {
-				client.Dispatch(fmt.Sprintf("movewindoworgroup %s", direction))
+				client.DispatchAll(hyprland.MoveWindowOrGroup(direction))
 			} else {
-				client.Dispatch("movegroupwindow b")
+				client.DispatchAll(hyprland.MoveGroupWindow(false))
 			}
-		case "r", "d":
+		case hyprland.DirectionRight, hyprland.DirectionDown:
 			if addr == grouped[len(grouped)-1] {
-				client.Dispatch(fmt.Sprintf("movewindoworgroup %s", direction))
+				client.DispatchAll(hyprland.MoveWindowOrGroup(direction))
 			} else {
-				client.Dispatch("movegroupwindow f")
+				client.DispatchAll(hyprland.MoveGroupWindow(true))
 			}
 		default:
 			log.Printf("Unknown direction '%s'. Valid options are: l, r, u, d.", direction)
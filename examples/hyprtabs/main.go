@@ -7,8 +7,6 @@
 package main
 
 import (
-	"fmt"
-
 	"github.com/thiagokokada/hyprland-go"
 )
 
@@ -24,23 +22,25 @@ func must(err error) {
 }
 
 func main() {
-	client := hyprland.MustClient()
+	hyprtabs(hyprland.MustClient())
+}
 
+func hyprtabs(client *hyprland.RequestClient) {
 	aWindow := must1(client.ActiveWindow())
 	if len(aWindow.Grouped) > 0 {
-		must1(client.Dispatch(
+		must1(client.DispatchAll(
 			// If we are already in a group, ungroup
-			"togglegroup",
+			hyprland.ToggleGroup(),
 			// Make the current window as master (when using master layout)
-			"layoutmsg swapwithmaster master",
+			hyprland.LayoutMsg("swapwithmaster master"),
 		))
 	} else {
-		var cmdbuf []string
+		var cmdbuf []hyprland.Dispatcher
 		aWorkspace := must1(client.ActiveWorkspace())
 		clients := must1(client.Clients())
 
 		// Grab all windows in the active workspace
-		var windows []string
+		var windows []hyprland.WindowAddress
 		for _, c := range clients {
 			if c.Workspace.Id == aWorkspace.Id {
 				windows = append(windows, c.Address)
@@ -48,7 +48,7 @@ func main() {
 		}
 
 		// Start by creating a new group
-		cmdbuf = append(cmdbuf, "togglegroup")
+		cmdbuf = append(cmdbuf, hyprland.ToggleGroup())
 		for _, w := range windows {
 			// Move each window inside the group
 			// Once is not enough in case of very "deep" layouts,
@@ -60,20 +60,20 @@ func main() {
 			// supported moving windows based on address and not
 			// only positions
 			for i := 0; i < 2; i++ {
-				cmdbuf = append(cmdbuf, fmt.Sprintf("focuswindow address:%s", w))
-				cmdbuf = append(cmdbuf, "layoutmsg swapwithmaster auto")
-				cmdbuf = append(cmdbuf, "moveintogroup l")
-				cmdbuf = append(cmdbuf, "moveintogroup r")
-				cmdbuf = append(cmdbuf, "moveintogroup u")
-				cmdbuf = append(cmdbuf, "moveintogroup d")
+				cmdbuf = append(cmdbuf, hyprland.FocusWindow(hyprland.WindowByAddress(w)))
+				cmdbuf = append(cmdbuf, hyprland.LayoutMsg("swapwithmaster auto"))
+				cmdbuf = append(cmdbuf, hyprland.MoveIntoGroup(hyprland.DirectionLeft))
+				cmdbuf = append(cmdbuf, hyprland.MoveIntoGroup(hyprland.DirectionRight))
+				cmdbuf = append(cmdbuf, hyprland.MoveIntoGroup(hyprland.DirectionUp))
+				cmdbuf = append(cmdbuf, hyprland.MoveIntoGroup(hyprland.DirectionDown))
 			}
 		}
 		// Focus in the active window at the end
-		cmdbuf = append(cmdbuf, fmt.Sprintf("focuswindow address:%s", aWindow.Address))
+		cmdbuf = append(cmdbuf, hyprland.FocusWindow(hyprland.WindowByAddress(aWindow.Address)))
 
 		// Dispatch buffered commands in one call for performance,
 		// hyprland-go will take care of splitting it in smaller calls
 		// if necessary
-		must1(client.Dispatch(cmdbuf...))
+		must1(client.DispatchAll(cmdbuf...))
 	}
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestHyprtabs(t *testing.T) {
+	c := hyprlandtest.NewCompositor(t, hyprlandtest.State{})
+	client := c.Client()
+
+	_, err := client.DispatchAll(
+		hyprland.Exec("kitty"),
+		hyprland.Exec("firefox"),
+		hyprland.FocusWorkspace(hyprland.WorkspaceByID(2)),
+		hyprland.Exec("foot"),
+		hyprland.FocusWorkspace(hyprland.WorkspaceByID(1)),
+		hyprland.Exec("emacs"),
+	)
+	assert.NoError(t, err)
+
+	active := must1(client.ActiveWindow())
+	assert.Equal(t, active.Class, "emacs")
+
+	// Group
+	hyprtabs(client)
+
+	groups := map[string][]hyprland.WindowAddress{}
+	for _, cl := range c.State().Clients {
+		groups[cl.Class] = cl.Grouped
+	}
+
+	want := []hyprland.WindowAddress{"0x1000", "0x1010", "0x1030"} // kitty, firefox, emacs
+	assert.Equal(t, len(groups), 4)
+	assert.Equal(t, len(groups["foot"]), 0)
+
+	for _, class := range []string{"kitty", "firefox", "emacs"} {
+		assert.Equal(t, len(groups[class]), len(want))
+
+		for _, addr := range want {
+			assert.True(t, contains(groups[class], addr))
+		}
+	}
+
+	active = must1(client.ActiveWindow())
+	assert.Equal(t, active.Class, "emacs")
+
+	// Ungroup
+	hyprtabs(client)
+
+	for _, cl := range c.State().Clients {
+		assert.Equal(t, len(cl.Grouped), 0)
+	}
+}
+
+func contains(s []hyprland.WindowAddress, v hyprland.WindowAddress) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
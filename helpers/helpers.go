@@ -18,18 +18,28 @@ func GetSocket(socket Socket) (string, error) {
 		return "", fmt.Errorf("%w, are you using Hyprland?", ErrEmptyHis)
 	}
 
-	// https://github.com/hyprwm/Hyprland/blob/83a5395eaa99fecef777827fff1de486c06b6180/hyprctl/main.cpp#L53-L62
-	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
-
-	u, err := user.Current()
+	runtimeDir, err := GetRuntimeDir()
 	if err != nil {
-		return "", fmt.Errorf("error while getting the current user: %w", err)
+		return "", err
 	}
 
+	return filepath.Join(runtimeDir, his, string(socket)), nil
+}
+
+// Returns the Hyprland runtime directory, where each instance has a
+// directory named after its HYPRLAND_INSTANCE_SIGNATURE.
+func GetRuntimeDir() (string, error) {
+	// https://github.com/hyprwm/Hyprland/blob/83a5395eaa99fecef777827fff1de486c06b6180/hyprctl/main.cpp#L53-L62
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+
 	if runtimeDir == "" {
-		user := u.Uid
-		runtimeDir = filepath.Join("/run/user", user)
+		u, err := user.Current()
+		if err != nil {
+			return "", fmt.Errorf("error while getting the current user: %w", err)
+		}
+
+		runtimeDir = filepath.Join("/run/user", u.Uid)
 	}
 
-	return filepath.Join(runtimeDir, "hypr", his, string(socket)), nil
+	return filepath.Join(runtimeDir, "hypr"), nil
 }
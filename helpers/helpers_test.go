@@ -42,3 +42,11 @@ func TestGetSocketError(t *testing.T) {
 	_, err := GetSocket(RequestSocket)
 	assert.Error(t, err)
 }
+
+func TestGetRuntimeDir(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/xdg")
+
+	dir, err := GetRuntimeDir()
+	assert.NoError(t, err)
+	assert.Equal(t, dir, "/xdg/hypr")
+}
@@ -0,0 +1,721 @@
+package hyprlandtest
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go"
+)
+
+// Compositor is a [Server] that simulates a compositor: instead of only
+// answering "ok" for dispatchers, it applies the most common ones to its
+// [State] and emits the matching events, similar to Hyprland.
+//
+// The following dispatchers are simulated: workspace, movetoworkspace,
+// movetoworkspacesilent, focuswindow, togglefloating, togglegroup,
+// moveintogroup, moveoutofgroup, closewindow, killactive and exec. Any
+// other dispatcher is accepted and ignored.
+//
+// The output create and remove commands are also simulated, adding and
+// removing monitors without workspaces, and so are the descriptions,
+// getoption and keyword commands, see [Compositor.SetOptions].
+//
+// Since there is no geometry in the simulation, moveintogroup moves the
+// active window into the first group in the same workspace regardless of
+// the direction. Also, switching to an empty workspace keeps the previous
+// window focused.
+type Compositor struct {
+	*Server
+
+	nextAddress       uint64
+	previousWorkspace hyprland.WorkspaceID
+	nextOutput        int
+
+	descriptions []hyprland.Description
+	schema       hyprland.OptionSchema
+	options      map[string]hyprland.Option
+}
+
+type simulatedEvent struct {
+	name, data string
+}
+
+// NewCompositor starts a new fake server with a simulated compositor, that
+// will be closed automatically at the end of the test.
+// If state has no monitor, a single 'DP-1' monitor is created, and if it has
+// no workspace, the workspace 1 is created in the first monitor.
+func NewCompositor(tb testing.TB, state State) *Compositor {
+	tb.Helper()
+
+	c := &Compositor{Server: NewServer(tb), nextAddress: 0x1000}
+
+	if len(state.Monitors) == 0 {
+		state.Monitors = []hyprland.Monitor{{Name: "DP-1", Focused: true}}
+	}
+
+	if len(state.Workspaces) == 0 {
+		m := &state.Monitors[0]
+		m.ActiveWorkspace = hyprland.WorkspaceType{Id: 1, Name: "1"}
+		state.Workspaces = []hyprland.Workspace{{
+			WorkspaceType: m.ActiveWorkspace,
+			Monitor:       m.Name,
+			MonitorID:     m.Id,
+		}}
+	}
+
+	c.SetState(state)
+	c.Update(func(st *State) { st.updateWorkspaces() })
+	c.Handle("dispatch", func(_ *Server, req Request) string {
+		return c.dispatch(req.Args)
+	})
+	c.Handle("output", func(_ *Server, req Request) string {
+		return c.output(req.Args)
+	})
+	c.Handle("descriptions", func(*Server, Request) string {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		return mustMarshal(nonNil(c.descriptions))
+	})
+	c.Handle("getoption", func(_ *Server, req Request) string {
+		return c.getOption(req.Args)
+	})
+	c.Handle("keyword", func(_ *Server, req Request) string {
+		return c.keyword(req.Args)
+	})
+
+	return c
+}
+
+// Apply a dispatcher to the state, returning the response and emitting the
+// resulting events.
+func (c *Compositor) dispatch(args string) string {
+	name, params, _ := strings.Cut(args, " ")
+	params = strings.TrimSpace(params)
+
+	c.mu.Lock()
+
+	var (
+		events []simulatedEvent
+		err    error
+	)
+
+	st := &c.state
+
+	switch name {
+	case "workspace":
+		events, err = c.focusWorkspace(st, params)
+	case "movetoworkspace", "movetoworkspacesilent":
+		ws, w, _ := strings.Cut(params, ",")
+		events, err = c.moveToWorkspace(st, ws, w, name == "movetoworkspacesilent")
+	case "focuswindow":
+		events, err = c.focusWindow(st, params)
+	case "togglefloating":
+		events, err = toggleFloating(st, params)
+	case "togglegroup":
+		events, err = toggleGroup(st)
+	case "moveintogroup":
+		events, err = moveIntoGroup(st)
+	case "moveoutofgroup":
+		events, err = moveOutOfGroup(st, params)
+	case "closewindow":
+		events, err = c.closeWindow(st, params)
+	case "killactive":
+		events, err = c.closeWindow(st, "")
+	case "exec":
+		events, err = c.exec(st, params)
+	}
+
+	st.updateWorkspaces()
+	c.mu.Unlock()
+
+	if err != nil {
+		return err.Error()
+	}
+
+	for _, e := range events {
+		c.Emit(e.name, e.data)
+	}
+
+	return "ok"
+}
+
+// Apply an output command to the state, e.g.: 'create headless foo' or
+// 'remove foo'.
+func (c *Compositor) output(args string) string {
+	fields := strings.Fields(args)
+	if len(fields) < 2 {
+		return "not enough args"
+	}
+
+	c.mu.Lock()
+
+	var (
+		events []simulatedEvent
+		err    error
+	)
+
+	st := &c.state
+
+	switch fields[0] {
+	case "create":
+		events, err = c.createOutput(st, fields[1], strings.Join(fields[2:], " "))
+	case "remove":
+		events, err = removeOutput(st, fields[1])
+	default:
+		err = fmt.Errorf("unknown output command: %s", fields[0])
+	}
+
+	c.mu.Unlock()
+
+	if err != nil {
+		return err.Error()
+	}
+
+	for _, e := range events {
+		c.Emit(e.name, e.data)
+	}
+
+	return "ok"
+}
+
+func (c *Compositor) createOutput(st *State, backend, name string) ([]simulatedEvent, error) {
+	switch backend {
+	case "auto", "headless", "wayland", "x11":
+	default:
+		return nil, fmt.Errorf("unknown backend: %s", backend)
+	}
+
+	c.nextOutput++
+
+	if name == "" {
+		name = fmt.Sprintf("%s-%d", strings.ToUpper(backend), c.nextOutput)
+	}
+
+	var id hyprland.MonitorID
+
+	for _, m := range st.Monitors {
+		if m.Name == name {
+			return nil, fmt.Errorf("output %s already exists", name)
+		}
+
+		id = max(id, m.Id+1)
+	}
+
+	m := hyprland.Monitor{Id: id, Name: name, Description: "Virtual output " + name}
+	st.Monitors = append(st.Monitors, m)
+
+	return []simulatedEvent{
+		{"monitoradded", m.Name},
+		{"monitoraddedv2", fmt.Sprintf("%d,%s,%s", m.Id, m.Name, m.Description)},
+	}, nil
+}
+
+func removeOutput(st *State, name string) ([]simulatedEvent, error) {
+	for i, m := range st.Monitors {
+		if m.Name != name {
+			continue
+		}
+
+		st.Monitors = append(st.Monitors[:i:i], st.Monitors[i+1:]...)
+
+		return []simulatedEvent{
+			{"monitorremoved", m.Name},
+			{"monitorremovedv2", fmt.Sprintf("%d,%s,%s", m.Id, m.Name, m.Description)},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("output %s not found", name)
+}
+
+func (c *Compositor) focusWorkspace(st *State, selector string) ([]simulatedEvent, error) {
+	ws, events, err := c.workspace(st, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(events, c.switchWorkspace(st, ws)...), nil
+}
+
+func (c *Compositor) moveToWorkspace(st *State, wsSelector, wSelector string, silent bool) ([]simulatedEvent, error) {
+	i, err := st.findClient(wSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, events, err := c.workspace(st, wsSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cl := &st.Clients[i]
+	cl.Workspace = ws.WorkspaceType
+	cl.Monitor = ws.MonitorID
+	events = append(
+		events,
+		simulatedEvent{"movewindow", fmt.Sprintf("%s,%s", cl.Address.Hex(), ws.Name)},
+		simulatedEvent{"movewindowv2", fmt.Sprintf("%s,%d,%s", cl.Address.Hex(), ws.Id, ws.Name)},
+	)
+
+	if !silent {
+		events = append(events, c.switchWorkspace(st, ws)...)
+
+		if st.Clients[i].FocusHistoryId != 0 {
+			events = append(events, st.focus(i)...)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *Compositor) focusWindow(st *State, selector string) ([]simulatedEvent, error) {
+	i, err := st.findClient(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []simulatedEvent
+
+	if active, ok := st.activeWorkspace(); !ok || active.Id != st.Clients[i].Workspace.Id {
+		for _, ws := range st.Workspaces {
+			if ws.Id == st.Clients[i].Workspace.Id {
+				events = append(events, c.switchWorkspace(st, ws)...)
+			}
+		}
+	}
+
+	return append(events, st.focus(i)...), nil
+}
+
+func toggleFloating(st *State, selector string) ([]simulatedEvent, error) {
+	i, err := st.findClient(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	cl := &st.Clients[i]
+	cl.Floating = !cl.Floating
+
+	return []simulatedEvent{
+		{"changefloatingmode", cl.Address.Hex() + "," + boolEvent(cl.Floating)},
+	}, nil
+}
+
+func toggleGroup(st *State) ([]simulatedEvent, error) {
+	i, err := st.findClient("")
+	if err != nil {
+		return nil, err
+	}
+
+	cl := &st.Clients[i]
+
+	if len(cl.Grouped) == 0 {
+		cl.Grouped = []hyprland.WindowAddress{cl.Address}
+
+		return []simulatedEvent{{"togglegroup", "1," + cl.Address.Hex()}}, nil
+	}
+
+	group := cl.Grouped
+	addresses := make([]string, 0, len(group))
+
+	for _, addr := range group {
+		addresses = append(addresses, addr.Hex())
+
+		if j, err := st.findClient(string(hyprland.WindowByAddress(addr))); err == nil {
+			st.Clients[j].Grouped = nil
+		}
+	}
+
+	return []simulatedEvent{{"togglegroup", "0," + strings.Join(addresses, ",")}}, nil
+}
+
+func moveIntoGroup(st *State) ([]simulatedEvent, error) {
+	i, err := st.findClient("")
+	if err != nil {
+		return nil, err
+	}
+
+	cl := &st.Clients[i]
+	if len(cl.Grouped) > 0 {
+		return nil, nil
+	}
+
+	for _, other := range st.Clients {
+		if other.Workspace.Id != cl.Workspace.Id || len(other.Grouped) == 0 {
+			continue
+		}
+
+		group := append(append([]hyprland.WindowAddress(nil), other.Grouped...), cl.Address)
+		for j := range st.Clients {
+			if st.Clients[j].Address == cl.Address || contains(other.Grouped, st.Clients[j].Address) {
+				st.Clients[j].Grouped = group
+			}
+		}
+
+		return []simulatedEvent{{"moveintogroup", cl.Address.Hex()}}, nil
+	}
+
+	return nil, nil
+}
+
+func moveOutOfGroup(st *State, selector string) ([]simulatedEvent, error) {
+	i, err := st.findClient(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	cl := &st.Clients[i]
+	if len(cl.Grouped) == 0 {
+		return nil, nil
+	}
+
+	var group []hyprland.WindowAddress
+
+	for _, addr := range cl.Grouped {
+		if addr != cl.Address {
+			group = append(group, addr)
+		}
+	}
+
+	for j := range st.Clients {
+		if contains(cl.Grouped, st.Clients[j].Address) && j != i {
+			st.Clients[j].Grouped = group
+		}
+	}
+
+	cl.Grouped = nil
+
+	return []simulatedEvent{{"moveoutofgroup", cl.Address.Hex()}}, nil
+}
+
+func (c *Compositor) closeWindow(st *State, selector string) ([]simulatedEvent, error) {
+	i, err := st.findClient(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := st.Clients[i]
+	st.Clients = append(st.Clients[:i], st.Clients[i+1:]...)
+
+	for j := range st.Clients {
+		cl := &st.Clients[j]
+		if contains(closed.Grouped, cl.Address) {
+			cl.Grouped = remove(cl.Grouped, closed.Address)
+		}
+
+		if cl.FocusHistoryId > closed.FocusHistoryId {
+			cl.FocusHistoryId--
+		}
+	}
+
+	events := []simulatedEvent{{"closewindow", closed.Address.Hex()}}
+
+	if closed.FocusHistoryId == 0 {
+		if j, err := st.findClient(""); err == nil {
+			events = append(events, st.focus(j)...)
+		} else {
+			events = append(
+				events,
+				simulatedEvent{"activewindow", ","},
+				simulatedEvent{"activewindowv2", ""},
+			)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *Compositor) exec(st *State, cmd string) ([]simulatedEvent, error) {
+	// Ignore rules, e.g.: '[float] kitty'
+	if strings.HasPrefix(cmd, "[") {
+		if _, rest, found := strings.Cut(cmd, "]"); found {
+			cmd = strings.TrimSpace(rest)
+		}
+	}
+
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("exec: empty command") //nolint:err113
+	}
+
+	class := path.Base(fields[0])
+	ws, _ := st.activeWorkspace()
+
+	address := hyprland.WindowAddress("0x" + strconv.FormatUint(c.nextAddress, 16))
+	c.nextAddress += 0x10
+
+	st.Clients = append(st.Clients, hyprland.Client{
+		Address:        address,
+		Mapped:         true,
+		Workspace:      ws.WorkspaceType,
+		Monitor:        ws.MonitorID,
+		Class:          class,
+		Title:          cmd,
+		InitialClass:   class,
+		InitialTitle:   cmd,
+		Pid:            int(c.nextAddress),
+		FocusHistoryId: len(st.Clients),
+	})
+
+	events := []simulatedEvent{{
+		"openwindow",
+		fmt.Sprintf("%s,%s,%s,%s", address.Hex(), ws.Name, class, cmd),
+	}}
+
+	return append(events, st.focus(len(st.Clients)-1)...), nil
+}
+
+// Returns the workspace for a selector, creating it in the focused monitor
+// if needed.
+func (c *Compositor) workspace(st *State, selector string) (hyprland.Workspace, []simulatedEvent, error) {
+	active, _ := st.activeWorkspace()
+	var (
+		id   hyprland.WorkspaceID
+		name string
+	)
+
+	switch {
+	case selector == "previous":
+		id = c.previousWorkspace
+	case strings.HasPrefix(selector, "name:"):
+		name = strings.TrimPrefix(selector, "name:")
+	default:
+		// Relative workspaces, e.g.: '+1', 'm-1', 'r+1' and 'e+1'
+		relative := strings.TrimLeft(selector, "mre")
+		if n, err := strconv.Atoi(relative); err == nil {
+			if strings.HasPrefix(relative, "+") || strings.HasPrefix(relative, "-") {
+				id = max(active.Id+hyprland.WorkspaceID(n), 1)
+			} else {
+				id = hyprland.WorkspaceID(n)
+			}
+		}
+	}
+
+	for _, ws := range st.Workspaces {
+		if (name != "" && ws.Name == name) || (name == "" && ws.Id == id) {
+			return ws, nil, nil
+		}
+	}
+
+	if id <= 0 && name == "" {
+		return hyprland.Workspace{}, nil, fmt.Errorf("Invalid workspace: %s", selector) //nolint:err113
+	}
+
+	if name != "" {
+		for _, ws := range st.Workspaces {
+			id = max(id, ws.Id)
+		}
+
+		id++
+	} else {
+		name = id.String()
+	}
+
+	m := st.focusedMonitor()
+	ws := hyprland.Workspace{
+		WorkspaceType: hyprland.WorkspaceType{Id: id, Name: name},
+		Monitor:       m.Name,
+		MonitorID:     m.Id,
+	}
+	st.Workspaces = append(st.Workspaces, ws)
+
+	return ws, []simulatedEvent{
+		{"createworkspace", ws.Name},
+		{"createworkspacev2", fmt.Sprintf("%d,%s", ws.Id, ws.Name)},
+	}, nil
+}
+
+// Switch the focused monitor active workspace, destroying the previous one
+// if it is empty.
+func (c *Compositor) switchWorkspace(st *State, ws hyprland.Workspace) []simulatedEvent {
+	previous, _ := st.activeWorkspace()
+	if previous.Id == ws.Id {
+		return nil
+	}
+
+	c.previousWorkspace = previous.Id
+
+	for i := range st.Monitors {
+		if st.Monitors[i].Name == ws.Monitor {
+			st.Monitors[i].ActiveWorkspace = ws.WorkspaceType
+		}
+
+		st.Monitors[i].Focused = st.Monitors[i].Name == ws.Monitor
+	}
+
+	events := []simulatedEvent{
+		{"workspace", ws.Name},
+		{"workspacev2", fmt.Sprintf("%d,%s", ws.Id, ws.Name)},
+	}
+
+	st.updateWorkspaces()
+
+	for i, w := range st.Workspaces {
+		if w.Id == previous.Id && w.Windows == 0 {
+			st.Workspaces = append(st.Workspaces[:i], st.Workspaces[i+1:]...)
+			events = append(
+				events,
+				simulatedEvent{"destroyworkspace", w.Name},
+				simulatedEvent{"destroyworkspacev2", fmt.Sprintf("%d,%s", w.Id, w.Name)},
+			)
+
+			break
+		}
+	}
+
+	// Focus the last focused window in the new workspace
+	last := -1
+
+	for i, cl := range st.Clients {
+		if cl.Workspace.Id == ws.Id && (last < 0 || cl.FocusHistoryId < st.Clients[last].FocusHistoryId) {
+			last = i
+		}
+	}
+
+	if last >= 0 {
+		events = append(events, st.focus(last)...)
+	}
+
+	return events
+}
+
+// Focus the client at index i, updating the focus history.
+func (st *State) focus(i int) []simulatedEvent {
+	focused := st.Clients[i].FocusHistoryId
+
+	for j := range st.Clients {
+		if st.Clients[j].FocusHistoryId < focused {
+			st.Clients[j].FocusHistoryId++
+		}
+	}
+
+	cl := &st.Clients[i]
+	cl.FocusHistoryId = 0
+
+	return []simulatedEvent{
+		{"activewindow", cl.Class + "," + cl.Title},
+		{"activewindowv2", cl.Address.Hex()},
+	}
+}
+
+// Find a client by a window selector, returning its index. An empty selector
+// means the active window.
+func (st *State) findClient(selector string) (int, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" || selector == string(hyprland.ActiveWindowSelector) {
+		for i, cl := range st.Clients {
+			if cl.FocusHistoryId == 0 {
+				return i, nil
+			}
+		}
+
+		return -1, fmt.Errorf("No window found") //nolint:err113
+	}
+
+	kind, value, found := strings.Cut(selector, ":")
+	if !found {
+		kind, value = "class", selector
+	}
+
+	var match func(cl hyprland.Client) bool
+
+	switch kind {
+	case "address":
+		match = func(cl hyprland.Client) bool { return cl.Address.Equal(hyprland.WindowAddress(value)) }
+	case "pid":
+		match = func(cl hyprland.Client) bool { return strconv.Itoa(cl.Pid) == value }
+	case "class", "initialclass", "title", "initialtitle":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return -1, fmt.Errorf("Invalid regex: %w", err)
+		}
+
+		match = func(cl hyprland.Client) bool {
+			return re.MatchString(map[string]string{
+				"class":        cl.Class,
+				"initialclass": cl.InitialClass,
+				"title":        cl.Title,
+				"initialtitle": cl.InitialTitle,
+			}[kind])
+		}
+	default:
+		return -1, fmt.Errorf("Invalid window selector: %s", selector) //nolint:err113
+	}
+
+	for i, cl := range st.Clients {
+		if match(cl) {
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("No window found for: %s", selector) //nolint:err113
+}
+
+func (st *State) focusedMonitor() hyprland.Monitor {
+	for _, m := range st.Monitors {
+		if m.Focused {
+			return m
+		}
+	}
+
+	return st.Monitors[0]
+}
+
+// Update derived fields of workspaces, e.g.: the number of windows.
+func (st *State) updateWorkspaces() {
+	for i := range st.Workspaces {
+		ws := &st.Workspaces[i]
+		ws.Windows = 0
+		ws.LastWindow = "0x0"
+		ws.LastWindowTitle = ""
+		lastFocus := -1
+
+		for _, cl := range st.Clients {
+			if cl.Workspace.Id != ws.Id {
+				continue
+			}
+
+			ws.Windows++
+
+			if lastFocus < 0 || cl.FocusHistoryId < lastFocus {
+				lastFocus = cl.FocusHistoryId
+				ws.LastWindow = cl.Address
+				ws.LastWindowTitle = cl.Title
+			}
+		}
+	}
+}
+
+func boolEvent(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+func contains(s []hyprland.WindowAddress, v hyprland.WindowAddress) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remove(s []hyprland.WindowAddress, v hyprland.WindowAddress) []hyprland.WindowAddress {
+	var r []hyprland.WindowAddress
+
+	for _, e := range s {
+		if e != v {
+			r = append(r, e)
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,286 @@
+package hyprlandtest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/event"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func receiveN(t *testing.T, c *event.EventClient, n int) (events []event.ReceivedData) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for len(events) < n {
+		data, err := c.Receive(ctx)
+		assert.NoError(t, err)
+
+		events = append(events, data...)
+	}
+
+	return events
+}
+
+func TestCompositorExec(t *testing.T) {
+	s := NewCompositor(t, State{})
+	c := s.Client()
+
+	ec, err := event.NewClient(s.EventSocket)
+	assert.NoError(t, err)
+
+	defer ec.Close()
+
+	assert.NoError(t, s.WaitEventClients(1, time.Second))
+
+	_, err = c.DispatchAll(hyprland.Exec("kitty"), hyprland.Exec("[float] /usr/bin/foot --server"))
+	assert.NoError(t, err)
+
+	assert.DeepEqual(t, receiveN(t, ec, 6), []event.ReceivedData{
+		{Type: event.EventOpenWindow, Data: "1000,1,kitty,kitty"},
+		{Type: event.EventActiveWindow, Data: "kitty,kitty"},
+		{Type: event.EventActiveWindowV2, Data: "1000"},
+		{Type: event.EventOpenWindow, Data: "1010,1,foot,/usr/bin/foot --server"},
+		{Type: event.EventActiveWindow, Data: "foot,/usr/bin/foot --server"},
+		{Type: event.EventActiveWindowV2, Data: "1010"},
+	})
+
+	aw, err := c.ActiveWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, aw.Address, "0x1010")
+
+	ws, err := c.ActiveWorkspace()
+	assert.NoError(t, err)
+	assert.Equal(t, ws.Windows, 2)
+	assert.Equal(t, ws.LastWindow, "0x1010")
+}
+
+func TestCompositorExecEmpty(t *testing.T) {
+	s := NewCompositor(t, State{})
+	c := s.Client()
+
+	for _, d := range []hyprland.Dispatcher{hyprland.Exec(""), hyprland.Exec(" ", "float")} {
+		_, err := c.DispatchAll(d)
+		assert.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "exec: empty command"))
+	}
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.Equal(t, len(clients), 0)
+}
+
+func TestCompositorWorkspace(t *testing.T) {
+	s := NewCompositor(t, State{})
+	c := s.Client()
+
+	_, err := c.DispatchAll(hyprland.Exec("kitty"))
+	assert.NoError(t, err)
+
+	ec, err := event.NewClient(s.EventSocket)
+	assert.NoError(t, err)
+
+	defer ec.Close()
+
+	assert.NoError(t, s.WaitEventClients(1, time.Second))
+
+	_, err = c.DispatchAll(
+		hyprland.FocusWorkspace(hyprland.WorkspaceByName("web")),
+		hyprland.MoveWindowToWorkspace(hyprland.WorkspaceByID(1), hyprland.WindowByClass("kitty"), false),
+	)
+	assert.NoError(t, err)
+
+	assert.DeepEqual(t, receiveN(t, ec, 12), []event.ReceivedData{
+		{Type: event.EventCreateWorkspace, Data: "web"},
+		{Type: event.EventCreateWorkspaceV2, Data: "2,web"},
+		{Type: event.EventWorkspace, Data: "web"},
+		{Type: event.EventWorkspaceV2, Data: "2,web"},
+		{Type: event.EventMoveWindow, Data: "1000,1"},
+		{Type: event.EventMoveWindowV2, Data: "1000,1,1"},
+		{Type: event.EventWorkspace, Data: "1"},
+		{Type: event.EventWorkspaceV2, Data: "1,1"},
+		{Type: event.EventDestroyWorkspace, Data: "web"},
+		{Type: event.EventDestroyWorkspaceV2, Data: "2,web"},
+		{Type: event.EventActiveWindow, Data: "kitty,kitty"},
+		{Type: event.EventActiveWindowV2, Data: "1000"},
+	})
+
+	// The empty workspace is destroyed when leaving it
+	workspaces, err := c.Workspaces()
+	assert.NoError(t, err)
+	assert.Equal(t, len(workspaces), 1)
+
+	_, err = c.DispatchAll(hyprland.MoveToWorkspace(hyprland.WorkspaceByID(3), true))
+	assert.NoError(t, err)
+
+	ws, err := c.ActiveWorkspace()
+	assert.NoError(t, err)
+	assert.Equal(t, ws.Id, 1)
+	assert.Equal(t, ws.Windows, 0)
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.Equal(t, clients[0].Workspace.Id, 3)
+}
+
+func TestCompositorFocusWindow(t *testing.T) {
+	s := NewCompositor(t, testState)
+	c := s.Client()
+
+	_, err := c.DispatchAll(hyprland.FocusWindow(hyprland.WindowByClass("kitty")))
+	assert.NoError(t, err)
+
+	aw, err := c.ActiveWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, aw.Address, "0x1")
+
+	_, err = c.DispatchAll(hyprland.FocusWindow(hyprland.WindowByClass("foo")))
+	assert.Error(t, err)
+}
+
+func TestCompositorToggleFloating(t *testing.T) {
+	s := NewCompositor(t, testState)
+	c := s.Client()
+
+	_, err := c.DispatchAll(hyprland.ToggleFloating(hyprland.WindowByAddress("0x1")))
+	assert.NoError(t, err)
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.True(t, clients[0].Floating)
+	assert.False(t, clients[1].Floating)
+}
+
+func TestCompositorGroup(t *testing.T) {
+	s := NewCompositor(t, testState)
+	c := s.Client()
+
+	_, err := c.DispatchAll(
+		hyprland.ToggleGroup(),
+		hyprland.FocusWindow(hyprland.WindowByAddress("0x1")),
+		hyprland.MoveIntoGroup(hyprland.DirectionLeft),
+	)
+	assert.NoError(t, err)
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, clients[0].Grouped, []hyprland.WindowAddress{"0x2", "0x1"})
+	assert.DeepEqual(t, clients[1].Grouped, []hyprland.WindowAddress{"0x2", "0x1"})
+
+	_, err = c.DispatchAll(hyprland.MoveOutOfGroup(hyprland.WindowByAddress("0x1")))
+	assert.NoError(t, err)
+
+	clients, err = c.Clients()
+	assert.NoError(t, err)
+	assert.Equal(t, len(clients[0].Grouped), 0)
+	assert.DeepEqual(t, clients[1].Grouped, []hyprland.WindowAddress{"0x2"})
+
+	_, err = c.DispatchAll(
+		hyprland.FocusWindow(hyprland.WindowByAddress("0x2")),
+		hyprland.ToggleGroup(),
+	)
+	assert.NoError(t, err)
+
+	clients, err = c.Clients()
+	assert.NoError(t, err)
+	assert.Equal(t, len(clients[1].Grouped), 0)
+}
+
+func TestCompositorCloseWindow(t *testing.T) {
+	s := NewCompositor(t, testState)
+	c := s.Client()
+
+	ec, err := event.NewClient(s.EventSocket)
+	assert.NoError(t, err)
+
+	defer ec.Close()
+
+	assert.NoError(t, s.WaitEventClients(1, time.Second))
+
+	_, err = c.DispatchAll(hyprland.KillActive())
+	assert.NoError(t, err)
+
+	assert.DeepEqual(t, receiveN(t, ec, 3), []event.ReceivedData{
+		{Type: event.EventCloseWindow, Data: "2"},
+		{Type: event.EventActiveWindow, Data: "kitty,kitty"},
+		{Type: event.EventActiveWindowV2, Data: "1"},
+	})
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.Equal(t, len(clients), 1)
+	assert.Equal(t, clients[0].FocusHistoryId, 0)
+}
+
+func TestCompositorOptions(t *testing.T) {
+	s := NewCompositor(t, State{})
+	c := s.Client()
+
+	s.SetOptions(
+		[]hyprland.Description{
+			{Value: "general:border_size", Type: hyprland.OptionInt, Data: hyprland.DescriptionData{Min: 0, Max: 20}},
+			{Value: "general:col.active_border", Type: hyprland.OptionGradient},
+			{Value: "general:layout", Type: hyprland.OptionStringShort},
+			{Value: "master:new_status", Type: hyprland.OptionChoice, Data: hyprland.DescriptionData{Choices: "master,slave"}},
+		},
+		hyprland.Option{Option: "general:border_size", Int: 1},
+		hyprland.Option{Option: "general:col.active_border", Custom: "ffffffff 0deg"},
+		hyprland.Option{Option: "general:layout", Str: "dwindle"},
+		hyprland.Option{Option: "master:new_status", Int: 1},
+		hyprland.Option{Option: "plugin:foo:bar", Float: 1.5},
+	)
+
+	_, err := c.SetOptions(
+		hyprland.OptionValue{Name: "general:border_size", Value: 2},
+		hyprland.OptionValue{Name: "general:col.active_border", Value: hyprland.RGBA(0x33, 0xcc, 0xff, 0xee)},
+		hyprland.OptionValue{Name: "general:layout", Value: ""},
+		hyprland.OptionValue{Name: "master:new_status", Value: "master"},
+		hyprland.OptionValue{Name: "plugin:foo:bar", Value: 2.5},
+	)
+	assert.NoError(t, err)
+
+	got, err := c.GetOptionValues(
+		"general:border_size",
+		"general:col.active_border",
+		"general:layout",
+		"master:new_status",
+		"plugin:foo:bar",
+	)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, map[string]any{
+		"general:border_size":       2,
+		"general:col.active_border": hyprland.Gradient{Colors: []hyprland.Color{hyprland.RGBA(0x33, 0xcc, 0xff, 0xee)}},
+		"general:layout":            "",
+		"master:new_status":         "master",
+		"plugin:foo:bar":            2.5,
+	})
+
+	o, err := c.GetOption("general:col.active_border")
+	assert.NoError(t, err)
+	assert.Equal(t, o.Custom, "ee33ccff 0deg")
+	assert.True(t, o.Set)
+
+	// Unknown options, invalid and out of range values
+	for _, v := range []hyprland.OptionValue{
+		{Name: "foo:bar", Value: 1},
+		{Name: "general:border_size", Value: 21},
+		{Name: "general:col.active_border", Value: "foo"},
+		{Name: "master:new_status", Value: "inherit"},
+	} {
+		_, err = c.SetOptions(v)
+		assert.True(t, errors.Is(err, hyprland.ErrValidation))
+	}
+
+	_, err = c.GetOption("foo:bar")
+	assert.Error(t, err)
+
+	v, err := c.GetOptionValue("general:border_size")
+	assert.NoError(t, err)
+	assert.Equal(t, v, any(2))
+}
@@ -0,0 +1,186 @@
+package hyprlandtest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thiagokokada/hyprland-go"
+)
+
+// Value used in keyword to set an empty string, since the keyword command
+// needs a value.
+const emptyOptionValue = "[[EMPTY]]"
+
+// SetOptions replaces the configuration options of the compositor, used to
+// answer the descriptions, getoption and keyword commands.
+//
+// Values set with keyword are parsed according to the option description,
+// and rejected if they are invalid or out of the description range. Options
+// without a description (e.g.: from plugins) are parsed as floats if they
+// have a float value, or as ints otherwise.
+func (c *Compositor) SetOptions(descriptions []hyprland.Description, options ...hyprland.Option) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.descriptions = slices.Clone(descriptions)
+	c.schema = hyprland.NewOptionSchema(descriptions)
+	c.options = make(map[string]hyprland.Option, len(options))
+
+	for _, o := range options {
+		c.options[o.Option] = o
+	}
+}
+
+func (c *Compositor) getOption(name string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, ok := c.options[name]
+	if !ok {
+		return "no such option"
+	}
+
+	return mustMarshal(o)
+}
+
+// Set an option with keyword, e.g.: 'general:border_size 2'.
+func (c *Compositor) keyword(args string) string {
+	name, value, _ := strings.Cut(args, " ")
+	value = strings.TrimSpace(value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, ok := c.options[name]
+	if !ok {
+		return fmt.Sprintf("config option <%s> does not exist.", name)
+	}
+
+	if err := c.setOption(&o, value); err != nil {
+		return fmt.Sprintf("error setting %s: %s", name, err)
+	}
+
+	o.Set = true
+	c.options[name] = o
+
+	return "ok"
+}
+
+// Parse the value according to the option type and set it in o. Must be
+// called with the lock held.
+//
+//nolint:cyclop
+func (c *Compositor) setOption(o *hyprland.Option, value string) error {
+	if value == "" {
+		return fmt.Errorf("empty value") //nolint:err113
+	}
+
+	d, ok := c.schema[o.Option]
+	if !ok {
+		// Options without description keep the type of their value
+		d.Type = hyprland.OptionInt
+		if o.Float != 0 {
+			d.Type = hyprland.OptionFloat
+		}
+	}
+
+	var err error
+
+	switch d.Type {
+	case hyprland.OptionBool, hyprland.OptionInt:
+		if o.Int, err = parseInt(value); err == nil {
+			err = checkRange(float64(o.Int), d.Data.Min, d.Data.Max)
+		}
+	case hyprland.OptionFloat:
+		if o.Float, err = strconv.ParseFloat(value, 64); err == nil {
+			err = checkRange(o.Float, d.Data.Min, d.Data.Max)
+		}
+	case hyprland.OptionStringShort, hyprland.OptionStringLong:
+		o.Str = strings.ReplaceAll(value, emptyOptionValue, "")
+	case hyprland.OptionColor:
+		var color hyprland.Color
+		if color, err = hyprland.ParseColor(value); err == nil {
+			o.Int = int(argb(color))
+		}
+	case hyprland.OptionChoice:
+		o.Int, err = parseChoice(d.Data, value)
+	case hyprland.OptionGradient:
+		var g hyprland.Gradient
+		if g, err = hyprland.ParseGradient(value); err == nil {
+			o.Custom = formatGradient(g)
+		}
+	case hyprland.OptionVector:
+		var x, y float64
+		if _, err = fmt.Sscan(value, &x, &y); err == nil {
+			err = checkRange(x, d.Data.MinX, d.Data.MaxX)
+		}
+
+		if err == nil {
+			err = checkRange(y, d.Data.MinY, d.Data.MaxY)
+		}
+
+		o.Vec2 = []float64{x, y}
+	}
+
+	return err
+}
+
+// Parses an int, also accepting booleans (e.g.: 'true' or 'off'), similar to
+// Hyprland.
+func parseInt(value string) (int, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on":
+		return 1, nil
+	case "false", "no", "off":
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// Returns the index of a choice, given either by name or index.
+func parseChoice(data hyprland.DescriptionData, value string) (int, error) {
+	choices := strings.Split(data.Choices, ",")
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		if i = slices.Index(choices, value); i < 0 {
+			return 0, fmt.Errorf("invalid choice %s", value) //nolint:err113
+		}
+
+		i += data.FirstIndex
+	}
+
+	if i < data.FirstIndex || i >= data.FirstIndex+len(choices) {
+		return 0, fmt.Errorf("invalid choice %s", value) //nolint:err113
+	}
+
+	return i, nil
+}
+
+// Returns an error if v is not between minimum and maximum, if the range is
+// set.
+func checkRange(v, minimum, maximum float64) error {
+	if minimum < maximum && (v < minimum || v > maximum) {
+		return fmt.Errorf("value %v out of range [%v, %v]", v, minimum, maximum) //nolint:err113
+	}
+
+	return nil
+}
+
+// Colors are stored as ARGB integers, similar to Hyprland.
+func argb(c hyprland.Color) uint32 {
+	return uint32(c.A)<<24 | uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+}
+
+// Returns the gradient as returned by getoption, e.g.: 'ee33ccff 45deg'.
+func formatGradient(g hyprland.Gradient) string {
+	fields := make([]string, 0, len(g.Colors)+1)
+	for _, c := range g.Colors {
+		fields = append(fields, fmt.Sprintf("%08x", argb(c)))
+	}
+
+	return strings.Join(append(fields, strconv.Itoa(g.Angle)+"deg"), " ")
+}
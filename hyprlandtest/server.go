@@ -0,0 +1,547 @@
+// Package hyprlandtest provides a fake Hyprland IPC server for testing code
+// that uses hyprland-go without a running compositor, similar to
+// [net/http/httptest].
+//
+// The server listens in a temporary directory in both the request socket
+// (.socket.sock) and event socket (.socket2.sock), answers queries (e.g.:
+// 'j/clients') from a programmable in-memory [State], records all requests
+// received and can push events to connected event clients.
+package hyprlandtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/helpers"
+)
+
+const (
+	// https://github.com/hyprwm/Hyprland/blob/918d8340afd652b011b937d29d5eea0be08467f5/hyprctl/main.cpp#L278
+	batch = "[[BATCH]]"
+	// https://github.com/hyprwm/Hyprland/blob/918d8340afd652b011b937d29d5eea0be08467f5/hyprctl/main.cpp#L257
+	bufSize = 8192
+	// Instance signature used by the fake server, see [Server.Setenv].
+	Signature = "hyprlandtest"
+)
+
+// Request is a single command received by the server, after the batch
+// and flags are parsed.
+type Request struct {
+	// Raw command, without flags, e.g.: 'dispatch exec kitty'.
+	Raw string
+	// Command name, e.g.: 'dispatch'.
+	Command string
+	// Command arguments, e.g.: 'exec kitty'.
+	Args string
+	// True if the request had the 'j/' flag.
+	JSON bool
+}
+
+// HandlerFunc handles a [Request], returning the response that will be
+// written to the socket.
+type HandlerFunc func(s *Server, req Request) string
+
+// State is the in-memory state used to answer queries.
+// The active window is the client with FocusHistoryId 0, and the active
+// workspace is the active workspace of the focused monitor.
+type State struct {
+	Clients    []hyprland.Client
+	Workspaces []hyprland.Workspace
+	Monitors   []hyprland.Monitor
+}
+
+// Server is a fake Hyprland IPC server.
+type Server struct {
+	// Directory used as XDG_RUNTIME_DIR.
+	RuntimeDir string
+	// Path for the request socket, to be used in [hyprland.NewClient].
+	RequestSocket string
+	// Path for the event socket, to be used in event.NewClient.
+	EventSocket string
+
+	reqListener net.Listener
+	evListener  net.Listener
+	wg          sync.WaitGroup
+
+	mu         sync.Mutex
+	state      State
+	handlers   map[string]HandlerFunc
+	requests   []hyprland.RawRequest
+	commands   []Request
+	eventConns []net.Conn
+	eventCond  *sync.Cond
+	closed     bool
+}
+
+// NewServer starts a new fake server, that will be closed automatically at
+// the end of the test.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	// Avoid tb.TempDir() since Unix sockets paths are limited to 108
+	// characters
+	dir, err := os.MkdirTemp("", "hyprlandtest")
+	if err != nil {
+		tb.Fatalf("error while creating temporary dir: %v", err)
+	}
+
+	sockDir := filepath.Join(dir, "hypr", Signature)
+	if err := os.MkdirAll(sockDir, 0o700); err != nil {
+		tb.Fatalf("error while creating socket dir: %v", err)
+	}
+
+	s := &Server{
+		RuntimeDir:    dir,
+		RequestSocket: filepath.Join(sockDir, string(helpers.RequestSocket)),
+		EventSocket:   filepath.Join(sockDir, string(helpers.EventSocket)),
+		handlers:      defaultHandlers(),
+	}
+	s.eventCond = sync.NewCond(&s.mu)
+
+	s.reqListener, err = net.Listen("unix", s.RequestSocket)
+	if err != nil {
+		tb.Fatalf("error while listening to request socket: %v", err)
+	}
+
+	s.evListener, err = net.Listen("unix", s.EventSocket)
+	if err != nil {
+		tb.Fatalf("error while listening to event socket: %v", err)
+	}
+
+	s.wg.Add(2)
+
+	go s.acceptRequests()
+	go s.acceptEvents()
+
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// Close the server, closing all connections and removing the temporary
+// directory.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.closed = true
+	for _, conn := range s.eventConns {
+		conn.Close()
+	}
+
+	s.eventConns = nil
+	s.eventCond.Broadcast()
+	s.mu.Unlock()
+
+	s.reqListener.Close()
+	s.evListener.Close()
+	s.wg.Wait()
+
+	os.RemoveAll(s.RuntimeDir)
+}
+
+// Setenv sets HYPRLAND_INSTANCE_SIGNATURE and XDG_RUNTIME_DIR to point to
+// this server, so [hyprland.MustClient] and event.MustClient connects to it.
+func (s *Server) Setenv(tb testing.TB) {
+	tb.Helper()
+
+	tb.Setenv("HYPRLAND_INSTANCE_SIGNATURE", Signature)
+	tb.Setenv("XDG_RUNTIME_DIR", s.RuntimeDir)
+}
+
+// Client returns a new [hyprland.RequestClient] connected to this server.
+func (s *Server) Client() *hyprland.RequestClient {
+	return hyprland.NewClient(s.RequestSocket)
+}
+
+// State returns a copy of the current server state.
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.clone()
+}
+
+// SetState replaces the current server state.
+func (s *Server) SetState(state State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = state.clone()
+}
+
+// Update the current server state in place.
+func (s *Server) Update(f func(state *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f(&s.state)
+}
+
+// Handle registers a handler for a command (e.g.: 'dispatch'), replacing
+// the default one if it exists.
+func (s *Server) Handle(command string, f HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[command] = f
+}
+
+// SetResponse registers a static response for a command (e.g.: 'splash').
+func (s *Server) SetResponse(command string, response string) {
+	s.Handle(command, func(*Server, Request) string { return response })
+}
+
+// Requests returns all raw requests received by the server, i.e.: one for
+// each connection.
+func (s *Server) Requests() []hyprland.RawRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]hyprland.RawRequest(nil), s.requests...)
+}
+
+// Commands returns all commands received by the server, with batches
+// split in individual commands.
+func (s *Server) Commands() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.commands...)
+}
+
+// Reset the recorded requests and commands.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.commands = nil
+}
+
+// WaitEventClients blocks until at least n event clients are connected, or
+// the timeout expires.
+func (s *Server) WaitEventClients(n int, timeout time.Duration) error {
+	timer := time.AfterFunc(timeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.eventCond.Broadcast()
+	})
+	defer timer.Stop()
+
+	deadline := time.Now().Add(timeout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.eventConns) < n {
+		if s.closed {
+			return net.ErrClosed
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"timeout while waiting for event clients: want: %d, got: %d",
+				n,
+				len(s.eventConns),
+			)
+		}
+
+		s.eventCond.Wait()
+	}
+
+	return nil
+}
+
+// Emit an event to all connected event clients, e.g.:
+// Emit("workspacev2", "1,1") will send 'workspacev2>>1,1\n'.
+func (s *Server) Emit(event string, data string) error {
+	return s.EmitRaw([]byte(event + ">>" + data + "\n"))
+}
+
+// EmitRaw writes raw bytes to all connected event clients, useful to test
+// how clients handles malformed events.
+func (s *Server) EmitRaw(data []byte) error {
+	s.mu.Lock()
+	conns := append([]net.Conn(nil), s.eventConns...)
+	s.mu.Unlock()
+
+	var err error
+
+	for _, conn := range conns {
+		if _, e := conn.Write(data); e != nil {
+			err = errors.Join(err, fmt.Errorf("error while writing event: %w", e))
+		}
+	}
+
+	return err
+}
+
+func (s *Server) acceptRequests() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.reqListener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+
+			s.serveRequest(conn)
+		}()
+	}
+}
+
+func (s *Server) acceptEvents() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.evListener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			conn.Close()
+		} else {
+			s.eventConns = append(s.eventConns, conn)
+			s.eventCond.Broadcast()
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *Server) serveRequest(conn net.Conn) {
+	// Similar to Hyprland, only a single read is done
+	buf := make([]byte, bufSize)
+
+	n, err := conn.Read(buf)
+	if err != nil {
+		return
+	}
+
+	raw := string(buf[:n])
+
+	s.mu.Lock()
+	s.requests = append(s.requests, hyprland.RawRequest(raw))
+	s.mu.Unlock()
+
+	var response string
+
+	if strings.HasPrefix(raw, batch) {
+		// Similar to Hyprland, each reply is followed by an empty line
+		var sb strings.Builder
+
+		for _, cmd := range splitBatch(strings.TrimPrefix(raw, batch)) {
+			sb.WriteString(s.reply(cmd))
+			sb.WriteString("\n\n")
+		}
+
+		response = sb.String()
+	} else {
+		response = s.reply(raw)
+	}
+
+	conn.Write([]byte(response))
+}
+
+func (s *Server) reply(raw string) string {
+	req := parseRequest(raw)
+
+	s.mu.Lock()
+	s.commands = append(s.commands, req)
+	handler, ok := s.handlers[req.Command]
+	s.mu.Unlock()
+
+	if !ok {
+		return "unknown request"
+	}
+
+	return handler(s, req)
+}
+
+// Split batch commands by ';', ignoring the ones inside '[]' (e.g.: exec
+// rules), similar to Hyprland.
+func splitBatch(raw string) (cmds []string) {
+	depth, start := 0, 0
+
+	for i, r := range raw {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth = max(depth-1, 0)
+		case ';':
+			if depth == 0 {
+				cmds = append(cmds, raw[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	cmds = append(cmds, raw[start:])
+
+	// Remove empty commands, e.g.: after the last ';'
+	nonEmpty := cmds[:0]
+
+	for _, cmd := range cmds {
+		if strings.TrimSpace(cmd) != "" {
+			nonEmpty = append(nonEmpty, cmd)
+		}
+	}
+
+	return nonEmpty
+}
+
+func parseRequest(raw string) Request {
+	raw = strings.TrimSpace(raw)
+
+	var req Request
+
+	// Flags are in the format 'j/', 'jr/', etc.
+	if flags, rest, found := strings.Cut(raw, "/"); found && !strings.Contains(flags, " ") {
+		req.JSON = strings.Contains(flags, "j")
+		raw = rest
+	}
+
+	req.Raw = raw
+	req.Command, req.Args, _ = strings.Cut(raw, " ")
+
+	return req
+}
+
+func defaultHandlers() map[string]HandlerFunc {
+	ok := func(*Server, Request) string { return "ok" }
+
+	return map[string]HandlerFunc{
+		"activewindow": func(s *Server, _ Request) string {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			for _, c := range s.state.Clients {
+				if c.FocusHistoryId == 0 {
+					return mustMarshal(c)
+				}
+			}
+
+			return "{}"
+		},
+		"activeworkspace": func(s *Server, _ Request) string {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			ws, _ := s.state.activeWorkspace()
+
+			return mustMarshal(ws)
+		},
+		"clients": func(s *Server, _ Request) string {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			return mustMarshal(nonNil(s.state.Clients))
+		},
+		"monitors": func(s *Server, _ Request) string {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			return mustMarshal(nonNil(s.state.Monitors))
+		},
+		"workspaces": func(s *Server, _ Request) string {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			return mustMarshal(nonNil(s.state.Workspaces))
+		},
+		"version": func(*Server, Request) string {
+			return mustMarshal(hyprland.Version{
+				Branch: "main",
+				Tag:    "v" + hyprland.HYPRLAND_VERSION,
+			})
+		},
+		"splash": func(*Server, Request) string {
+			return "Testing hyprland-go without Hyprland!"
+		},
+		"dismissnotify":   ok,
+		"dispatch":        ok,
+		"keyword":         ok,
+		"kill":            ok,
+		"notify":          ok,
+		"reload":          ok,
+		"setcursor":       ok,
+		"seterror":        ok,
+		"setprop":         ok,
+		"switchxkblayout": ok,
+	}
+}
+
+// Returns the active workspace, i.e.: the active workspace of the focused
+// monitor (or the first monitor if none is focused). Must be called with
+// the lock held.
+func (st *State) activeWorkspace() (hyprland.Workspace, bool) {
+	if len(st.Monitors) == 0 {
+		return hyprland.Workspace{}, false
+	}
+
+	monitor := st.Monitors[0]
+
+	for _, m := range st.Monitors {
+		if m.Focused {
+			monitor = m
+
+			break
+		}
+	}
+
+	for _, ws := range st.Workspaces {
+		if ws.Id == monitor.ActiveWorkspace.Id {
+			return ws, true
+		}
+	}
+
+	return hyprland.Workspace{}, false
+}
+
+func (st State) clone() State {
+	return State{
+		Clients:    append([]hyprland.Client(nil), st.Clients...),
+		Workspaces: append([]hyprland.Workspace(nil), st.Workspaces...),
+		Monitors:   append([]hyprland.Monitor(nil), st.Monitors...),
+	}
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+
+	return s
+}
+
+func mustMarshal(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
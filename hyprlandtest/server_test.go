@@ -0,0 +1,290 @@
+package hyprlandtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/event"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+var testState = State{
+	Clients: []hyprland.Client{
+		{
+			Address:        "0x1",
+			Class:          "kitty",
+			Title:          "kitty",
+			Workspace:      hyprland.WorkspaceType{Id: 1, Name: "1"},
+			FocusHistoryId: 1,
+		},
+		{
+			Address:        "0x2",
+			Class:          "firefox",
+			Title:          "Mozilla Firefox",
+			Workspace:      hyprland.WorkspaceType{Id: 1, Name: "1"},
+			FocusHistoryId: 0,
+		},
+	},
+	Workspaces: []hyprland.Workspace{
+		{WorkspaceType: hyprland.WorkspaceType{Id: 1, Name: "1"}, Monitor: "DP-1", Windows: 2},
+		{WorkspaceType: hyprland.WorkspaceType{Id: 2, Name: "2"}, Monitor: "DP-2", Windows: 0},
+	},
+	Monitors: []hyprland.Monitor{
+		{Id: 0, Name: "DP-1", ActiveWorkspace: hyprland.WorkspaceType{Id: 1, Name: "1"}, Focused: true},
+		{Id: 1, Name: "DP-2", ActiveWorkspace: hyprland.WorkspaceType{Id: 2, Name: "2"}},
+	},
+}
+
+func TestQueries(t *testing.T) {
+	s := NewServer(t)
+	s.SetState(testState)
+	c := s.Client()
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, clients, testState.Clients)
+
+	workspaces, err := c.Workspaces()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, workspaces, testState.Workspaces)
+
+	monitors, err := c.Monitors()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, monitors, testState.Monitors)
+
+	aw, err := c.ActiveWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, aw.Address, "0x2")
+
+	ws, err := c.ActiveWorkspace()
+	assert.NoError(t, err)
+	assert.Equal(t, ws.Id, 1)
+
+	v, err := c.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, v.Tag, "v"+hyprland.HYPRLAND_VERSION)
+
+	assert.DeepEqual(t, s.Commands(), []Request{
+		{Raw: "clients", Command: "clients", JSON: true},
+		{Raw: "workspaces", Command: "workspaces", JSON: true},
+		{Raw: "monitors all", Command: "monitors", Args: "all", JSON: true},
+		{Raw: "activewindow", Command: "activewindow", JSON: true},
+		{Raw: "activeworkspace", Command: "activeworkspace", JSON: true},
+		{Raw: "version", Command: "version", JSON: true},
+	})
+}
+
+func TestEmptyState(t *testing.T) {
+	s := NewServer(t)
+	c := s.Client()
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, clients, []hyprland.Client{})
+
+	aw, err := c.ActiveWindow()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, aw, hyprland.Window{})
+}
+
+func TestDispatch(t *testing.T) {
+	s := NewServer(t)
+	c := s.Client()
+
+	r, err := c.Dispatch("exec kitty")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, r, []hyprland.Response{"ok"})
+	assert.DeepEqual(t, s.Requests(), []hyprland.RawRequest{
+		hyprland.RawRequest("dispatch exec kitty"),
+	})
+
+	s.Reset()
+
+	r, err = c.DispatchAll(hyprland.ToggleGroup(), hyprland.MoveIntoGroup(hyprland.DirectionLeft))
+	assert.NoError(t, err)
+	assert.DeepEqual(t, r, []hyprland.Response{"ok", "ok"})
+	assert.DeepEqual(t, s.Requests(), []hyprland.RawRequest{
+		hyprland.RawRequest("[[BATCH]]dispatch togglegroup;dispatch moveintogroup l;"),
+	})
+	assert.DeepEqual(t, s.Commands(), []Request{
+		{Raw: "dispatch togglegroup", Command: "dispatch", Args: "togglegroup"},
+		{Raw: "dispatch moveintogroup l", Command: "dispatch", Args: "moveintogroup l"},
+	})
+}
+
+func TestDispatchExecRules(t *testing.T) {
+	s := NewServer(t)
+	c := s.Client()
+
+	_, err := c.DispatchAll(
+		hyprland.Exec("kitty", "workspace 2 silent", "float"),
+		hyprland.ToggleGroup(),
+	)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, s.Commands(), []Request{
+		{
+			Raw:     "dispatch exec [workspace 2 silent;float] kitty",
+			Command: "dispatch",
+			Args:    "exec [workspace 2 silent;float] kitty",
+		},
+		{Raw: "dispatch togglegroup", Command: "dispatch", Args: "togglegroup"},
+	})
+}
+
+func TestDispatchSplitBatch(t *testing.T) {
+	s := NewServer(t)
+	c := s.Client()
+
+	const want = 1000
+
+	params := make([]string, 0, want)
+	for i := 0; i < want; i++ {
+		params = append(params, fmt.Sprintf("exec kitty %d", i))
+	}
+
+	r, err := c.Dispatch(params...)
+	assert.NoError(t, err)
+	assert.Equal(t, len(r), want)
+	assert.Greater(t, len(s.Requests()), 1)
+	assert.Equal(t, len(s.Commands()), want)
+}
+
+func TestHandle(t *testing.T) {
+	s := NewServer(t)
+	s.Handle("dispatch", func(_ *Server, req Request) string {
+		if strings.HasPrefix(req.Args, "exec") {
+			return "ok"
+		}
+
+		return "Invalid dispatcher"
+	})
+	s.SetResponse("splash", "foo")
+	c := s.Client()
+
+	_, err := c.Dispatch("exec kitty", "foo")
+	assert.Error(t, err)
+
+	splash, err := c.Splash()
+	assert.NoError(t, err)
+	assert.Equal(t, splash, "foo")
+
+	_, err = c.RawRequest(hyprland.RawRequest("foo"))
+	assert.NoError(t, err)
+}
+
+func TestSetenv(t *testing.T) {
+	s := NewServer(t)
+	s.SetState(testState)
+	s.Setenv(t)
+
+	c := hyprland.MustClient()
+
+	clients, err := c.Clients()
+	assert.NoError(t, err)
+	assert.Equal(t, len(clients), 2)
+}
+
+func TestEmit(t *testing.T) {
+	s := NewServer(t)
+
+	c, err := event.NewClient(s.EventSocket)
+	assert.NoError(t, err)
+
+	defer c.Close()
+
+	assert.NoError(t, s.WaitEventClients(1, time.Second))
+	assert.NoError(t, s.Emit("workspacev2", "1,1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	data, err := c.Receive(ctx)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, data, []event.ReceivedData{
+		{Type: event.EventWorkspaceV2, Data: "1,1"},
+	})
+}
+
+func TestWaitEventClientsTimeout(t *testing.T) {
+	s := NewServer(t)
+
+	assert.Error(t, s.WaitEventClients(1, 10*time.Millisecond))
+}
+
+func BenchmarkClients(b *testing.B) {
+	s := NewServer(b)
+	s.SetState(testState)
+	c := s.Client()
+
+	for i := 0; i < b.N; i++ {
+		c.Clients()
+	}
+}
+
+func TestQuery(t *testing.T) {
+	s := NewServer(t)
+	s.SetState(testState)
+	c := s.Client()
+
+	var (
+		clients    []hyprland.Client
+		workspaces []hyprland.Workspace
+		monitors   []hyprland.Monitor
+	)
+
+	err := c.Query(
+		hyprland.ClientsQuery(&clients),
+		hyprland.WorkspacesQuery(&workspaces),
+		hyprland.MonitorsQuery(&monitors),
+	)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, clients, testState.Clients)
+	assert.DeepEqual(t, workspaces, testState.Workspaces)
+	assert.DeepEqual(t, monitors, testState.Monitors)
+	assert.DeepEqual(t, s.Requests(), []hyprland.RawRequest{
+		hyprland.RawRequest("[[BATCH]]j/clients;j/workspaces;j/monitors all;"),
+	})
+
+	var w hyprland.Window
+
+	err = c.Query(hyprland.NewQuery("foo", &w))
+	assert.Error(t, err)
+}
+
+// Compare with BenchmarkQuery, that does the same queries in a single
+// connection.
+func BenchmarkQuerySeparate(b *testing.B) {
+	s := NewServer(b)
+	s.SetState(testState)
+	c := s.Client()
+
+	for i := 0; i < b.N; i++ {
+		c.Clients()
+		c.Workspaces()
+		c.Monitors()
+	}
+}
+
+func BenchmarkQuery(b *testing.B) {
+	s := NewServer(b)
+	s.SetState(testState)
+	c := s.Client()
+
+	var (
+		clients    []hyprland.Client
+		workspaces []hyprland.Workspace
+		monitors   []hyprland.Monitor
+	)
+
+	for i := 0; i < b.N; i++ {
+		c.Query(
+			hyprland.ClientsQuery(&clients),
+			hyprland.WorkspacesQuery(&workspaces),
+			hyprland.MonitorsQuery(&monitors),
+		)
+	}
+}
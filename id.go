@@ -0,0 +1,84 @@
+package hyprland
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WindowAddress is the address of a window, e.g.: '0x80864f60'.
+// Hyprland uses the '0x' prefix in requests but not in events (e.g.:
+// '80864f60'), so use [ParseWindowAddress] to get a normalised address, or
+// [WindowAddress.Equal] to compare addresses in different representations.
+type WindowAddress string
+
+// WorkspaceID is the ID of a workspace. Special workspaces have negative IDs.
+type WorkspaceID int
+
+// MonitorID is the ID of a monitor.
+type MonitorID int
+
+// ParseWindowAddress parses an hex address with or without the '0x' prefix,
+// returning it normalised with the prefix and in lowercase, e.g.:
+// '80864F60' returns '0x80864f60'.
+func ParseWindowAddress(s string) (WindowAddress, error) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid window address %q: %w", s, err)
+	}
+
+	return WindowAddress("0x" + strconv.FormatUint(n, 16)), nil
+}
+
+// Normalise returns the address with the '0x' prefix and in lowercase, or
+// the address unchanged if it is invalid.
+func (a WindowAddress) Normalise() WindowAddress {
+	if n, err := ParseWindowAddress(string(a)); err == nil {
+		return n
+	}
+
+	return a
+}
+
+// Equal returns true if both addresses are the same, regardless of the
+// representation, e.g.: '0x80864f60' and '80864f60'.
+func (a WindowAddress) Equal(b WindowAddress) bool {
+	return a.Normalise() == b.Normalise()
+}
+
+// Hex returns the address without the '0x' prefix, as used in events.
+func (a WindowAddress) Hex() string {
+	return strings.TrimPrefix(string(a.Normalise()), "0x")
+}
+
+func (a WindowAddress) String() string {
+	return string(a)
+}
+
+// ParseWorkspaceID parses a workspace ID, e.g.: '1' or '-98'.
+func ParseWorkspaceID(s string) (WorkspaceID, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid workspace ID %q: %w", s, err)
+	}
+
+	return WorkspaceID(id), nil
+}
+
+func (id WorkspaceID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// ParseMonitorID parses a monitor ID, e.g.: '0'.
+func ParseMonitorID(s string) (MonitorID, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid monitor ID %q: %w", s, err)
+	}
+
+	return MonitorID(id), nil
+}
+
+func (id MonitorID) String() string {
+	return strconv.Itoa(int(id))
+}
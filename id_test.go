@@ -0,0 +1,73 @@
+package hyprland
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestParseWindowAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    WindowAddress
+	}{
+		{"0x80864f60", "0x80864f60"},
+		{"80864f60", "0x80864f60"},
+		{"80864F60", "0x80864f60"},
+		{"0", "0x0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			a, err := ParseWindowAddress(tt.address)
+			assert.NoError(t, err)
+			assert.Equal(t, a, tt.want)
+			assert.Equal(t, a.Hex(), string(tt.want[2:]))
+		})
+	}
+
+	for _, address := range []string{"", "0x", "foo", "0x-1"} {
+		_, err := ParseWindowAddress(address)
+		assert.Error(t, err)
+	}
+}
+
+func TestWindowAddressEqual(t *testing.T) {
+	assert.True(t, WindowAddress("0x80864f60").Equal("80864f60"))
+	assert.True(t, WindowAddress("80864F60").Equal("0x80864f60"))
+	assert.False(t, WindowAddress("0x80864f60").Equal("0x80864f61"))
+	assert.True(t, WindowAddress("").Equal(""))
+	assert.False(t, WindowAddress("foo").Equal("0x0"))
+}
+
+func TestParseIDs(t *testing.T) {
+	ws, err := ParseWorkspaceID("-98")
+	assert.NoError(t, err)
+	assert.Equal(t, ws, -98)
+	assert.Equal(t, ws.String(), "-98")
+
+	m, err := ParseMonitorID("1")
+	assert.NoError(t, err)
+	assert.Equal(t, m, 1)
+	assert.Equal(t, m.String(), "1")
+
+	_, err = ParseWorkspaceID("name:foo")
+	assert.Error(t, err)
+
+	_, err = ParseMonitorID("")
+	assert.Error(t, err)
+}
+
+func TestIDsUnmarshal(t *testing.T) {
+	var cl Client
+
+	err := json.Unmarshal(
+		[]byte(`{"address":"0x80864f60","workspace":{"id":2,"name":"2"},"monitor":1,"grouped":["0x80864f60"]}`),
+		&cl,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, cl.Address, "0x80864f60")
+	assert.Equal(t, cl.Workspace.Id, 2)
+	assert.Equal(t, cl.Monitor, 1)
+	assert.DeepEqual(t, cl.Grouped, []WindowAddress{"0x80864f60"})
+}
@@ -0,0 +1,94 @@
+package hyprland
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thiagokokada/hyprland-go/helpers"
+)
+
+// Instance is a running Hyprland instance, see [Instances].
+type Instance struct {
+	Instance string `json:"instance"` // HYPRLAND_INSTANCE_SIGNATURE
+	Time     int64  `json:"time"`     // Unix time of when the instance started
+	Pid      int    `json:"pid"`
+	WlSocket string `json:"wl_socket"`
+}
+
+// Instances lists the running Hyprland instances of the current user, similar
+// to 'hyprctl instances'. The instances are sorted by start time.
+// Like hyprctl, this reads the lock file of each instance in the runtime
+// directory instead of doing a request, so it works without a [RequestClient].
+func Instances() (instances []Instance, err error) {
+	runtimeDir, err := helpers.GetRuntimeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(runtimeDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error while reading runtime dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		instance, ok := readInstance(filepath.Join(runtimeDir, entry.Name()))
+		if ok {
+			instances = append(instances, instance)
+		}
+	}
+
+	slices.SortFunc(instances, func(a, b Instance) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+
+	return instances, nil
+}
+
+// Reads the instance lock file, returning false if the instance is not
+// running. The lock file has the PID in the first line and the Wayland socket
+// in the second line, same as parsed by hyprctl.
+func readInstance(dir string) (instance Instance, ok bool) {
+	lock, err := os.ReadFile(filepath.Join(dir, "hyprland.lock"))
+	if err != nil {
+		return instance, false
+	}
+
+	instance.Instance = filepath.Base(dir)
+
+	// Signature is in the format '<commit>_<time>_<random>'
+	_, after, _ := strings.Cut(instance.Instance, "_")
+	instance.Time, err = strconv.ParseInt(after[:min(len(after), 10)], 10, 64)
+	if err != nil {
+		return instance, false
+	}
+
+	lines := strings.Split(string(lock), "\n")
+	instance.Pid, _ = strconv.Atoi(lines[0])
+	if len(lines) > 1 {
+		instance.WlSocket = lines[1]
+	}
+
+	if instance.Pid <= 0 {
+		return instance, false
+	}
+
+	if _, err := os.Stat(filepath.Join("/proc", strconv.Itoa(instance.Pid))); err != nil {
+		return instance, false
+	}
+
+	return instance, true
+}
@@ -0,0 +1,42 @@
+package hyprland
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestInstances(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+
+	instances, err := Instances()
+	assert.NoError(t, err)
+	assert.Equal(t, len(instances), 0)
+
+	pid := strconv.Itoa(os.Getpid())
+	locks := map[string]string{
+		"abc_1738000200_2": pid + "\nwayland-2\n",
+		"abc_1738000100_1": pid + "\nwayland-1\n",
+		"abc_1738000300_3": "0\nwayland-3\n",      // invalid PID
+		"invalid":          pid + "\nwayland-4\n", // invalid signature
+	}
+
+	for his, lock := range locks {
+		dir := filepath.Join(runtimeDir, "hypr", his)
+		assert.NoError(t, os.MkdirAll(dir, 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "hyprland.lock"), []byte(lock), 0o600))
+	}
+	// Directory without lock file
+	assert.NoError(t, os.MkdirAll(filepath.Join(runtimeDir, "hypr", "abc_1738000400_4"), 0o700))
+
+	instances, err = Instances()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, instances, []Instance{
+		{Instance: "abc_1738000100_1", Time: 1738000100, Pid: os.Getpid(), WlSocket: "wayland-1"},
+		{Instance: "abc_1738000200_2", Time: 1738000200, Pid: os.Getpid(), WlSocket: "wayland-2"},
+	})
+}
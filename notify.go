@@ -0,0 +1,125 @@
+package hyprland
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// NotifyIcon is the icon shown in a [Notification].
+type NotifyIcon int
+
+const (
+	NotifyIconNone NotifyIcon = iota - 1
+	NotifyIconWarning
+	NotifyIconInfo
+	NotifyIconHint
+	NotifyIconError
+	NotifyIconConfused
+	NotifyIconOk
+)
+
+// Notification is an on-screen notification shown by Hyprland, see
+// [RequestClient.Notify].
+type Notification struct {
+	Icon NotifyIcon
+	// How long the notification is shown, rounded to milliseconds.
+	Timeout time.Duration
+	// Zero value means the default color for the icon.
+	Color Color
+	// Zero value means the default font size.
+	FontSize int
+	Message  string
+}
+
+// String returns the notification in the format expected by notify, e.g.:
+// '1 5000 rgba(33ccffee) fontsize:20 Hello'.
+func (n Notification) String() string {
+	color := "0"
+	if n.Color != (Color{}) {
+		color = n.Color.String()
+	}
+
+	message := n.Message
+	if n.FontSize > 0 {
+		message = "fontsize:" + strconv.Itoa(n.FontSize) + " " + message
+	}
+
+	return joinArgs(
+		" ",
+		strconv.Itoa(int(n.Icon)),
+		strconv.FormatInt(n.Timeout.Milliseconds(), 10),
+		color,
+		message,
+	)
+}
+
+// Notify command, similar to 'hyprctl notify'.
+// Shows a notification, e.g.:
+// c.Notify(Notification{Icon: NotifyIconInfo, Timeout: 5 * time.Second, Message: "Hello"}).
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) Notify(n Notification) (r Response, err error) {
+	return c.NotifyContext(context.Background(), n)
+}
+
+// NotifyContext is like [RequestClient.Notify], but with a context.
+func (c *RequestClient) NotifyContext(ctx context.Context, n Notification) (r Response, err error) {
+	return c.doSingleRequest(ctx, "notify", n.String())
+}
+
+// Dismiss notify command, similar to 'hyprctl dismissnotify'.
+// Dismisses the oldest count notifications, or all of them if count is zero
+// or negative.
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) DismissNotify(count int) (r Response, err error) {
+	return c.DismissNotifyContext(context.Background(), count)
+}
+
+// DismissNotifyContext is like [RequestClient.DismissNotify], but with a context.
+func (c *RequestClient) DismissNotifyContext(ctx context.Context, count int) (r Response, err error) {
+	if count <= 0 {
+		count = -1
+	}
+
+	return c.doSingleRequest(ctx, "dismissnotify", strconv.Itoa(count))
+}
+
+// Set error command, similar to 'hyprctl seterror'.
+// Shows an error overlay at the top of the screen, similar to the one shown
+// for configuration errors, until [RequestClient.DisableError] is called.
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) SetError(color Color, message string) (r Response, err error) {
+	return c.SetErrorContext(context.Background(), color, message)
+}
+
+// SetErrorContext is like [RequestClient.SetError], but with a context.
+func (c *RequestClient) SetErrorContext(ctx context.Context, color Color, message string) (r Response, err error) {
+	return c.doSingleRequest(ctx, "seterror", joinArgs(" ", color.String(), message))
+}
+
+// Disable error command, similar to 'hyprctl seterror disable'.
+// Hides the error overlay shown by [RequestClient.SetError].
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) DisableError() (r Response, err error) {
+	return c.DisableErrorContext(context.Background())
+}
+
+// DisableErrorContext is like [RequestClient.DisableError], but with a context.
+func (c *RequestClient) DisableErrorContext(ctx context.Context) (r Response, err error) {
+	return c.doSingleRequest(ctx, "seterror", "disable")
+}
+
+// Does a request with a single param and validates the response.
+func (c *RequestClient) doSingleRequest(ctx context.Context, command string, param string) (r Response, err error) {
+	raw, err := c.doRequest(ctx, command, []string{param}, false)
+	if err != nil {
+		return r, err
+	}
+
+	response, err := parseAndValidateResponse([]string{param}, raw)
+	if len(response) == 0 {
+		return r, err
+	}
+
+	return response[0], err // should return only one response
+}
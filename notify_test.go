@@ -0,0 +1,72 @@
+package hyprland_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestNotification(t *testing.T) {
+	tests := []struct {
+		n    hyprland.Notification
+		want string
+	}{
+		{hyprland.Notification{Icon: hyprland.NotifyIconNone, Message: "Hello"}, "-1 0 0 Hello"},
+		{
+			hyprland.Notification{
+				Icon:     hyprland.NotifyIconInfo,
+				Timeout:  5 * time.Second,
+				Color:    hyprland.RGB(0xff, 0x1e, 0xa3),
+				FontSize: 20,
+				Message:  "Hello world",
+			},
+			"1 5000 rgba(ff1ea3ff) fontsize:20 Hello world",
+		},
+		{hyprland.Notification{Icon: hyprland.NotifyIconOk, Timeout: 1500 * time.Microsecond}, "5 1 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.n.String(), tt.want)
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	c := s.Client()
+
+	r, err := c.Notify(hyprland.Notification{Icon: hyprland.NotifyIconWarning, Timeout: time.Second, Message: "Hi"})
+	assert.NoError(t, err)
+	assert.Equal(t, r, "ok")
+
+	_, err = c.DismissNotify(2)
+	assert.NoError(t, err)
+	_, err = c.DismissNotify(0)
+	assert.NoError(t, err)
+	_, err = c.SetError(hyprland.RGBA(0xff, 0, 0, 0xcc), "Something went wrong")
+	assert.NoError(t, err)
+	_, err = c.DisableError()
+	assert.NoError(t, err)
+
+	var got []string
+	for _, cmd := range s.Commands() {
+		got = append(got, cmd.Raw)
+	}
+
+	assert.DeepEqual(t, got, []string{
+		"notify 0 1000 0 Hi",
+		"dismissnotify 2",
+		"dismissnotify -1",
+		"seterror rgba(ff0000cc) Something went wrong",
+		"seterror disable",
+	})
+
+	s.SetResponse("notify", "invalid icon")
+
+	_, err = c.Notify(hyprland.Notification{Icon: 42})
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+}
@@ -0,0 +1,321 @@
+package hyprland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value used by Hyprland for empty string options.
+const emptyOptionValue = "[[EMPTY]]"
+
+// OptionValue is a configuration option with a typed value, e.g.:
+// OptionValue{"general:border_size", 2}. See [FormatOptionValue] for the
+// supported types.
+type OptionValue struct {
+	Name  string
+	Value any
+}
+
+// FormatOptionValue formats a value in the format expected by keyword.
+// Supported types are bool, int, int64, float32, float64, string, [Color],
+// [Gradient] and [Vec2]. Empty strings are formatted as '[[EMPTY]]', since
+// Hyprland does not accept empty values.
+// Returns an error wrapping [ErrInvalidValue] for other types.
+func FormatOptionValue(value any) (string, error) {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return formatFloat(v), nil
+	case string:
+		if v == "" {
+			return emptyOptionValue, nil
+		}
+
+		return v, nil
+	case Color:
+		return v.String(), nil
+	case Gradient:
+		return v.String(), nil
+	case Vec2:
+		return v.String(), nil
+	}
+
+	return "", fmt.Errorf("%w: unsupported option value %v (%T)", ErrInvalidValue, value, value)
+}
+
+// Set option command, similar to 'hyprctl keyword <name> <value>', with the
+// value formatted by [FormatOptionValue], e.g.:
+// c.SetOption("general:col.active_border", Gradient{[]Color{RGB(255, 0, 0)}, 45}).
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) SetOption(name string, value any) (r Response, err error) {
+	return c.SetOptionContext(context.Background(), name, value)
+}
+
+// SetOptionContext is like [RequestClient.SetOption], but with a context.
+func (c *RequestClient) SetOptionContext(ctx context.Context, name string, value any) (r Response, err error) {
+	response, err := c.SetOptionsContext(ctx, OptionValue{name, value})
+	if len(response) == 0 {
+		return r, err
+	}
+
+	return response[0], err
+}
+
+// Set options command, sets multiple options in a single batch request, see
+// [RequestClient.SetOption].
+// All values are formatted before sending: the returned error joins one error
+// wrapping [ErrInvalidValue] for each value that could not be formatted, and
+// in this case nothing is sent. If Hyprland did not accept some options, the
+// returned error is a [BatchError] listing each of them.
+// Returns a [Response] list for each option, that may be useful for further
+// validations.
+func (c *RequestClient) SetOptions(options ...OptionValue) (r []Response, err error) {
+	return c.SetOptionsContext(context.Background(), options...)
+}
+
+// SetOptionsContext is like [RequestClient.SetOptions], but with a context.
+func (c *RequestClient) SetOptionsContext(ctx context.Context, options ...OptionValue) (r []Response, err error) {
+	params := make([]string, 0, len(options))
+
+	for _, o := range options {
+		s, formatErr := FormatOptionValue(o.Value)
+		if formatErr != nil {
+			err = errors.Join(err, fmt.Errorf("option %s: %w", o.Name, formatErr))
+		}
+
+		params = append(params, o.Name+" "+s)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := c.doRequest(ctx, "keyword", params, false)
+	if err != nil {
+		return r, err
+	}
+
+	return parseAndValidateResponse(params, raw)
+}
+
+// OptionSchema maps each configuration option name to its [Description],
+// used to decode option values to their types.
+type OptionSchema map[string]Description
+
+// NewOptionSchema creates an [OptionSchema] from the descriptions returned by
+// [RequestClient.Descriptions].
+func NewOptionSchema(descriptions []Description) OptionSchema {
+	schema := make(OptionSchema, len(descriptions))
+	for _, d := range descriptions {
+		schema[d.Value] = d
+	}
+
+	return schema
+}
+
+// Option schema command, similar to 'hyprctl descriptions'.
+// Returns an [OptionSchema], that can be reused to decode multiple options.
+// The schema is also cached by the client for [RequestClient.GetOptionValues].
+func (c *RequestClient) OptionSchema() (s OptionSchema, err error) {
+	return c.OptionSchemaContext(context.Background())
+}
+
+// OptionSchemaContext is like [RequestClient.OptionSchema], but with a context.
+func (c *RequestClient) OptionSchemaContext(ctx context.Context) (s OptionSchema, err error) {
+	descriptions, err := c.DescriptionsContext(ctx)
+	if err != nil {
+		return s, err
+	}
+
+	s = NewOptionSchema(descriptions)
+	c.setSchema(s)
+
+	return s, nil
+}
+
+// Returns the cached option schema, or nil if it was not fetched yet.
+func (c *RequestClient) cachedSchema() OptionSchema {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+
+	return c.schema
+}
+
+// Caches the option schema, a nil schema invalidates the cache (e.g.: after
+// loading a plugin, that may add new options).
+func (c *RequestClient) setSchema(s OptionSchema) {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+
+	c.schema = s
+}
+
+// Decode returns the value of the option with the Go type matching its
+// [OptionType]:
+//   - [OptionBool]: bool
+//   - [OptionInt]: int
+//   - [OptionFloat]: float64
+//   - [OptionStringShort] and [OptionStringLong]: string
+//   - [OptionColor]: [Color]
+//   - [OptionChoice]: string, the selected choice
+//   - [OptionGradient]: [Gradient]
+//   - [OptionVector]: [Vec2]
+//
+// Options without description (e.g.: from plugins) are decoded from the
+// fields that are set, similar to [Option.String], as int, float64, string or
+// [Vec2].
+//
+//nolint:cyclop
+func (s OptionSchema) Decode(o Option) (any, error) {
+	d, ok := s[o.Option]
+	if !ok {
+		return decodeUnknownOption(o), nil
+	}
+
+	switch d.Type {
+	case OptionBool:
+		return o.Int != 0, nil
+	case OptionInt:
+		return o.Int, nil
+	case OptionFloat:
+		return o.Float, nil
+	case OptionStringShort, OptionStringLong:
+		if o.Str == "" {
+			return o.Custom, nil
+		}
+
+		return o.Str, nil
+	case OptionColor:
+		// Colors are stored as ARGB integers
+		n := uint32(o.Int)
+
+		return RGBA(uint8(n>>16), uint8(n>>8), uint8(n), uint8(n>>24)), nil
+	case OptionChoice:
+		choices := strings.Split(d.Data.Choices, ",")
+		if i := o.Int - d.Data.FirstIndex; i >= 0 && i < len(choices) {
+			return choices[i], nil
+		}
+
+		return nil, fmt.Errorf("%w: choice %d for option %s: %s", ErrInvalidValue, o.Int, o.Option, d.Data.Choices)
+	case OptionGradient:
+		return ParseGradient(o.Custom)
+	case OptionVector:
+		if len(o.Vec2) != 2 {
+			return nil, fmt.Errorf("%w: vec2 %v for option %s", ErrInvalidValue, o.Vec2, o.Option)
+		}
+
+		return Vec2{o.Vec2[0], o.Vec2[1]}, nil
+	}
+
+	return decodeUnknownOption(o), nil
+}
+
+func decodeUnknownOption(o Option) any {
+	switch {
+	case o.Custom != "":
+		return o.Custom
+	case o.Str != "":
+		return o.Str
+	case len(o.Vec2) == 2:
+		return Vec2{o.Vec2[0], o.Vec2[1]}
+	case o.Float != 0:
+		return o.Float
+	}
+
+	return o.Int
+}
+
+// Get option value command, similar to [RequestClient.GetOption], but
+// returns the value decoded to its Go type, see [OptionSchema.Decode].
+// The schema is queried together with the option in the first call, and
+// cached by the client for the next ones.
+func (c *RequestClient) GetOptionValue(name string) (v any, err error) {
+	return c.GetOptionValueContext(context.Background(), name)
+}
+
+// GetOptionValueContext is like [RequestClient.GetOptionValue], but with a context.
+func (c *RequestClient) GetOptionValueContext(ctx context.Context, name string) (v any, err error) {
+	values, err := c.GetOptionValuesContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[name], nil
+}
+
+// Get option values command, similar to [RequestClient.GetOptionValue] for
+// multiple options, that are queried in a single request.
+// Returns a map from each option name to its value. If some options could not
+// be queried (e.g.: they do not exist), the returned error joins one error
+// naming each of them.
+func (c *RequestClient) GetOptionValues(names ...string) (v map[string]any, err error) {
+	return c.GetOptionValuesContext(context.Background(), names...)
+}
+
+// GetOptionValuesContext is like [RequestClient.GetOptionValues], but with a context.
+func (c *RequestClient) GetOptionValuesContext(ctx context.Context, names ...string) (v map[string]any, err error) {
+	schema, options, err := c.queryOptions(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	v = make(map[string]any, len(names))
+
+	for i, o := range options {
+		v[names[i]], err = schema.Decode(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// Queries the options in a single request, together with the schema if it is
+// not cached yet.
+func (c *RequestClient) queryOptions(ctx context.Context, names []string) (OptionSchema, []Option, error) {
+	var (
+		descriptions []Description
+		queries      []Query
+	)
+
+	schema := c.cachedSchema()
+	if schema == nil {
+		queries = append(queries, DescriptionsQuery(&descriptions))
+	}
+
+	options := make([]Option, len(names))
+	for i, name := range names {
+		queries = append(queries, GetOptionQuery(name, &options[i]))
+	}
+
+	err := c.QueryContext(ctx, queries...)
+
+	// The schema is cached even if some options failed
+	if schema == nil && len(descriptions) > 0 {
+		schema = NewOptionSchema(descriptions)
+		c.setSchema(schema)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range options {
+		if options[i].Option == "" {
+			options[i].Option = names[i]
+		}
+	}
+
+	return schema, options, nil
+}
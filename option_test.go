@@ -0,0 +1,213 @@
+package hyprland_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+// Starts a compositor with the descriptions from testdata and some options.
+func newOptionCompositor(t *testing.T) *hyprlandtest.Compositor {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", "descriptions.json"))
+	assert.NoError(t, err)
+
+	var descriptions []hyprland.Description
+	assert.NoError(t, json.Unmarshal(b, &descriptions))
+
+	s := hyprlandtest.NewCompositor(t, hyprlandtest.State{})
+	s.SetOptions(
+		descriptions,
+		hyprland.Option{Option: "general:border_size", Int: 2, Set: true},
+		hyprland.Option{Option: "general:no_border_on_floating", Int: 1, Set: true},
+		hyprland.Option{Option: "general:col.active_border", Custom: "ee33ccff ee00ff99 45deg", Set: true},
+		hyprland.Option{Option: "general:layout", Str: "master", Set: true},
+		hyprland.Option{Option: "general:gaps_in", Int: 5, Set: true},
+		hyprland.Option{Option: "decoration:active_opacity", Float: 0.9, Set: true},
+		hyprland.Option{Option: "decoration:shadow:offset", Vec2: []float64{2, 3}, Set: true},
+		hyprland.Option{Option: "master:new_status", Int: 1, Set: true},
+		hyprland.Option{Option: "misc:vrr", Int: 0, Set: true},
+		hyprland.Option{Option: "plugin:foo:bar", Float: 1.5, Set: true},
+	)
+
+	return s
+}
+
+// Returns the raw commands received by the server.
+func rawCommands(s *hyprlandtest.Compositor) (raw []string) {
+	for _, cmd := range s.Commands() {
+		raw = append(raw, cmd.Raw)
+	}
+
+	return raw
+}
+
+func TestFormatOptionValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{true, "true"},
+		{false, "false"},
+		{2, "2"},
+		{int64(-1), "-1"},
+		{float32(0.5), "0.5"},
+		{0.25, "0.25"},
+		{"dwindle", "dwindle"},
+		{"", "[[EMPTY]]"},
+		{hyprland.RGBA(0x33, 0xcc, 0xff, 0xee), "rgba(33ccffee)"},
+		{
+			hyprland.Gradient{
+				Colors: []hyprland.Color{hyprland.RGBA(0x33, 0xcc, 0xff, 0xee), hyprland.RGBA(0x00, 0xff, 0x99, 0xee)},
+				Angle:  45,
+			},
+			"rgba(33ccffee) rgba(00ff99ee) 45deg",
+		},
+		{hyprland.Vec2{X: 0, Y: 2.5}, "0 2.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := hyprland.FormatOptionValue(tt.value)
+			assert.NoError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+
+	for _, v := range []any{nil, []int{1, 2}, uint8(1)} {
+		_, err := hyprland.FormatOptionValue(v)
+		assert.True(t, errors.Is(err, hyprland.ErrInvalidValue))
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	s := newOptionCompositor(t)
+	c := s.Client()
+
+	r, err := c.SetOption("general:border_size", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, r, "ok")
+
+	_, err = c.SetOptions(
+		hyprland.OptionValue{Name: "general:gaps_in", Value: 5},
+		hyprland.OptionValue{Name: "decoration:shadow:offset", Value: hyprland.Vec2{X: 1, Y: 2}},
+		hyprland.OptionValue{Name: "misc:vrr", Value: true},
+	)
+	assert.NoError(t, err)
+
+	assert.DeepEqual(t, rawCommands(s), []string{
+		"keyword general:border_size 2",
+		"keyword general:gaps_in 5",
+		"keyword decoration:shadow:offset 1 2",
+		"keyword misc:vrr true",
+	})
+
+	// Each failed option is reported
+	r2, err := c.SetOptions(
+		hyprland.OptionValue{Name: "foo:bar", Value: 1},
+		hyprland.OptionValue{Name: "general:gaps_in", Value: 5},
+		hyprland.OptionValue{Name: "foo:baz", Value: 1},
+	)
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+
+	var batchErr *hyprland.BatchError
+	assert.True(t, errors.As(err, &batchErr))
+	assert.Equal(t, len(batchErr.Failures), 2)
+	assert.Equal(t, batchErr.Failures[0].Index, 0)
+	assert.Equal(t, batchErr.Failures[0].Param, "foo:bar 1")
+	assert.Equal(t, batchErr.Failures[1].Index, 2)
+	assert.Equal(t, batchErr.Failures[1].Param, "foo:baz 1")
+	assert.False(t, strings.Contains(err.Error(), "general:gaps_in"))
+	assert.Equal(t, len(r2), 3)
+	assert.Equal(t, r2[1], "ok")
+
+	// Invalid values are not sent
+	s.Reset()
+
+	_, err = c.SetOptions(
+		hyprland.OptionValue{Name: "general:gaps_in", Value: 5},
+		hyprland.OptionValue{Name: "general:gaps_out", Value: []int{5}},
+	)
+	assert.True(t, errors.Is(err, hyprland.ErrInvalidValue))
+	assert.Equal(t, len(s.Requests()), 0)
+}
+
+func TestGetOptionValues(t *testing.T) {
+	s := newOptionCompositor(t)
+	c := s.Client()
+
+	got, err := c.GetOptionValues(
+		"general:border_size",
+		"general:no_border_on_floating",
+		"general:col.active_border",
+		"general:layout",
+		"decoration:active_opacity",
+		"decoration:shadow:offset",
+		"master:new_status",
+		"plugin:foo:bar",
+	)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, map[string]any{
+		"general:border_size":           2,
+		"general:no_border_on_floating": true,
+		"general:col.active_border": hyprland.Gradient{
+			Colors: []hyprland.Color{hyprland.RGBA(0x33, 0xcc, 0xff, 0xee), hyprland.RGBA(0x00, 0xff, 0x99, 0xee)},
+			Angle:  45,
+		},
+		"general:layout":            "master",
+		"decoration:active_opacity": 0.9,
+		"decoration:shadow:offset":  hyprland.Vec2{X: 2, Y: 3},
+		"master:new_status":         "slave",
+		"plugin:foo:bar":            1.5,
+	})
+	// Single request
+	assert.Equal(t, len(s.Requests()), 1)
+
+	v, err := c.GetOptionValue("general:border_size")
+	assert.NoError(t, err)
+	assert.Equal(t, v, any(2))
+
+	// The schema is cached
+	raw := rawCommands(s)
+	assert.DeepEqual(t, raw[len(raw)-1:], []string{"getoption general:border_size"})
+
+	// Each unknown option is reported, without the other responses
+	_, err = c.GetOptionValues("foo:bar", "general:border_size", "foo:baz")
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+	assert.True(t, strings.Contains(err.Error(), "'getoption foo:bar'"))
+	assert.True(t, strings.Contains(err.Error(), "'getoption foo:baz'"))
+	assert.False(t, strings.Contains(err.Error(), "general:border_size"))
+
+	// Loading plugins invalidates the cache
+	s.SetResponse("plugin", "ok")
+
+	_, err = c.PluginLoad("/plugins/foo.so")
+	assert.NoError(t, err)
+
+	s.Reset()
+
+	_, err = c.GetOptionValue("plugin:foo:bar")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, rawCommands(s), []string{"descriptions", "getoption plugin:foo:bar"})
+}
+
+func TestOptionSchemaDecodeColor(t *testing.T) {
+	schema := hyprland.NewOptionSchema([]hyprland.Description{
+		{Value: "group:col.border_active", Type: hyprland.OptionColor},
+		{Value: "master:orientation", Type: hyprland.OptionChoice, Data: hyprland.DescriptionData{Choices: "left,right"}},
+	})
+
+	v, err := schema.Decode(hyprland.Option{Option: "group:col.border_active", Int: 0xee33ccff})
+	assert.NoError(t, err)
+	assert.Equal(t, v, any(hyprland.RGBA(0x33, 0xcc, 0xff, 0xee)))
+
+	_, err = schema.Decode(hyprland.Option{Option: "master:orientation", Int: 2})
+	assert.True(t, errors.Is(err, hyprland.ErrInvalidValue))
+}
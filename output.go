@@ -0,0 +1,149 @@
+package hyprland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Returned when the output created by [RequestClient.CreateOutput] does not
+// show up in the monitors list.
+var ErrOutputNotFound = errors.New("created output not found")
+
+// OutputBackend is the backend used to create an output, see
+// [RequestClient.CreateOutput].
+type OutputBackend string
+
+const (
+	OutputBackendAuto     OutputBackend = "auto"
+	OutputBackendHeadless OutputBackend = "headless"
+	OutputBackendWayland  OutputBackend = "wayland"
+	OutputBackendX11      OutputBackend = "x11"
+)
+
+const (
+	// Interval and attempts used while waiting for the created output to
+	// show up in the monitors list.
+	outputPollInterval = 20 * time.Millisecond
+	outputPollAttempts = 50
+)
+
+// Output create command, similar to 'hyprctl output create'.
+// Creates a virtual output (e.g.: for screen sharing or testing) with an
+// optional name, and returns its [Monitor]. Since the output is added
+// asynchronously, this waits until it shows up in [RequestClient.Monitors].
+// Names can not have spaces.
+func (c *RequestClient) CreateOutput(backend OutputBackend, name string) (m Monitor, err error) {
+	return c.CreateOutputContext(context.Background(), backend, name)
+}
+
+// CreateOutputContext is like [RequestClient.CreateOutput], but with a context.
+func (c *RequestClient) CreateOutputContext(ctx context.Context, backend OutputBackend, name string) (m Monitor, err error) {
+	if strings.Contains(name, " ") {
+		return m, fmt.Errorf("%w: output name with spaces: %q", ErrValidation, name)
+	}
+
+	before, err := c.MonitorsContext(ctx)
+	if err != nil {
+		return m, err
+	}
+
+	_, err = c.doSingleRequest(ctx, "output", joinArgs(" ", "create", string(backend), name))
+	if err != nil {
+		return m, err
+	}
+
+	for i := 0; i < outputPollAttempts; i++ {
+		after, err := c.MonitorsContext(ctx)
+		if err != nil {
+			// The context may be done while waiting for the output
+			if ctx.Err() != nil {
+				return m, fmt.Errorf("%w: %w", ErrOutputNotFound, err)
+			}
+
+			return m, err
+		}
+
+		if m, ok := newMonitor(before, after, name); ok {
+			return m, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return m, fmt.Errorf("%w: %w", ErrOutputNotFound, ctx.Err())
+		case <-time.After(outputPollInterval):
+		}
+	}
+
+	return m, ErrOutputNotFound
+}
+
+// Output remove command, similar to 'hyprctl output remove'.
+// Removes an output created by [RequestClient.CreateOutput].
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) RemoveOutput(name string) (r Response, err error) {
+	return c.RemoveOutputContext(context.Background(), name)
+}
+
+// RemoveOutputContext is like [RequestClient.RemoveOutput], but with a context.
+func (c *RequestClient) RemoveOutputContext(ctx context.Context, name string) (r Response, err error) {
+	return c.doSingleRequest(ctx, "output", "remove "+name)
+}
+
+// TempOutput creates an output like [RequestClient.CreateOutput], that is
+// removed automatically when ctx is done.
+// The returned function removes the output immediately and can be called
+// multiple times, e.g.: with defer to make sure the output is removed before
+// the function returns. It returns the error from the removal, if any.
+func (c *RequestClient) TempOutput(ctx context.Context, backend OutputBackend, name string) (m Monitor, remove func() error, err error) {
+	m, err = c.CreateOutputContext(ctx, backend, name)
+	if err != nil {
+		return m, nil, err
+	}
+
+	var (
+		once      sync.Once
+		removeErr error
+	)
+
+	doRemove := func() error {
+		once.Do(func() {
+			// ctx may be already done at this point
+			_, removeErr = c.RemoveOutput(m.Name)
+		})
+
+		return removeErr
+	}
+
+	stop := context.AfterFunc(ctx, func() { doRemove() })
+
+	return m, func() error {
+		stop()
+
+		return doRemove()
+	}, nil
+}
+
+// Returns the monitor in after that is not in before, with the given name if
+// it is not empty.
+func newMonitor(before, after []Monitor, name string) (Monitor, bool) {
+	existing := make(map[string]bool, len(before))
+	for _, m := range before {
+		existing[m.Name] = true
+	}
+
+	for _, m := range after {
+		if existing[m.Name] {
+			continue
+		}
+
+		if name == "" || m.Name == name {
+			return m, true
+		}
+	}
+
+	return Monitor{}, false
+}
@@ -0,0 +1,97 @@
+package hyprland_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func monitorNames(t *testing.T, c *hyprland.RequestClient) (names []string) {
+	t.Helper()
+
+	monitors, err := c.Monitors()
+	assert.NoError(t, err)
+
+	for _, m := range monitors {
+		names = append(names, m.Name)
+	}
+
+	return names
+}
+
+func TestCreateOutput(t *testing.T) {
+	s := hyprlandtest.NewCompositor(t, hyprlandtest.State{})
+	c := s.Client()
+
+	m, err := c.CreateOutput(hyprland.OutputBackendHeadless, "")
+	assert.NoError(t, err)
+	assert.Equal(t, m.Name, "HEADLESS-1")
+	assert.Equal(t, m.Id, 1)
+
+	m, err = c.CreateOutput(hyprland.OutputBackendWayland, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, m.Name, "test")
+	assert.DeepEqual(t, monitorNames(t, c), []string{"DP-1", "HEADLESS-1", "test"})
+
+	_, err = c.CreateOutput(hyprland.OutputBackendHeadless, "test")
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+
+	_, err = c.CreateOutput(hyprland.OutputBackendHeadless, "foo bar")
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+
+	_, err = c.RemoveOutput("HEADLESS-1")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, monitorNames(t, c), []string{"DP-1", "test"})
+
+	_, err = c.RemoveOutput("HEADLESS-1")
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+}
+
+func TestCreateOutputNotFound(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	s.SetResponse("output", "ok")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := s.Client().CreateOutputContext(ctx, hyprland.OutputBackendHeadless, "")
+	assert.True(t, errors.Is(err, hyprland.ErrOutputNotFound))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestTempOutput(t *testing.T) {
+	s := hyprlandtest.NewCompositor(t, hyprlandtest.State{})
+	c := s.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, _, err := c.TempOutput(ctx, hyprland.OutputBackendHeadless, "ctx")
+	assert.NoError(t, err)
+
+	_, remove, err := c.TempOutput(context.Background(), hyprland.OutputBackendHeadless, "func")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, monitorNames(t, c), []string{"DP-1", "ctx", "func"})
+
+	// Removed when the function is called, only once
+	assert.NoError(t, remove())
+	assert.NoError(t, remove())
+	assert.DeepEqual(t, monitorNames(t, c), []string{"DP-1", "ctx"})
+
+	// Removed when the context is done
+	cancel()
+
+	for i := 0; len(monitorNames(t, c)) > 1; i++ {
+		if i > 100 {
+			t.Fatal("timeout while waiting for output removal")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.DeepEqual(t, monitorNames(t, c), []string{"DP-1"})
+}
@@ -0,0 +1,109 @@
+package hyprland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// Returned when loading a plugin that is already loaded.
+	ErrPluginAlreadyLoaded = errors.New("plugin already loaded")
+	// Returned when loading a plugin from a path that does not exist, or
+	// when unloading a plugin that is not loaded.
+	ErrPluginNotFound = errors.New("plugin not found")
+	// Returned when the plugin was built against a different Hyprland
+	// version.
+	ErrPluginABIMismatch = errors.New("plugin ABI mismatch")
+)
+
+// Plugin is a loaded Hyprland plugin, see [RequestClient.PluginList].
+type Plugin struct {
+	Name        string `json:"name"`
+	Author      string `json:"author"`
+	Handle      string `json:"handle"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+// Plugin load command, similar to 'hyprctl plugin load'.
+// Loads a plugin from path, that should be absolute since relative paths are
+// resolved by Hyprland.
+// Returns an error wrapping both a [BatchError] and one of the plugin errors
+// (e.g.: [ErrPluginAlreadyLoaded]) if the plugin could not be loaded.
+func (c *RequestClient) PluginLoad(path string) (r Response, err error) {
+	return c.PluginLoadContext(context.Background(), path)
+}
+
+// PluginLoadContext is like [RequestClient.PluginLoad], but with a context.
+func (c *RequestClient) PluginLoadContext(ctx context.Context, path string) (r Response, err error) {
+	return c.doPluginRequest(ctx, "load "+path)
+}
+
+// Plugin unload command, similar to 'hyprctl plugin unload'.
+// Unloads a plugin loaded from path.
+// Returns an error wrapping both a [BatchError] and [ErrPluginNotFound] if the
+// plugin is not loaded.
+func (c *RequestClient) PluginUnload(path string) (r Response, err error) {
+	return c.PluginUnloadContext(context.Background(), path)
+}
+
+// PluginUnloadContext is like [RequestClient.PluginUnload], but with a context.
+func (c *RequestClient) PluginUnloadContext(ctx context.Context, path string) (r Response, err error) {
+	return c.doPluginRequest(ctx, "unload "+path)
+}
+
+// Plugin list command, similar to 'hyprctl plugin list'.
+// Returns a [Plugin] object for each loaded plugin.
+func (c *RequestClient) PluginList() (p []Plugin, err error) {
+	return c.PluginListContext(context.Background())
+}
+
+// PluginListContext is like [RequestClient.PluginList], but with a context.
+func (c *RequestClient) PluginListContext(ctx context.Context) (p []Plugin, err error) {
+	response, err := c.doRequest(ctx, "plugin", []string{"list"}, true)
+	if err != nil {
+		return p, err
+	}
+	// Older versions return text even in JSON mode when there is no plugin
+	if strings.TrimSpace(string(response)) == "no plugins loaded" {
+		return nil, nil
+	}
+
+	return unmarshalResponse(response, &p)
+}
+
+func (c *RequestClient) doPluginRequest(ctx context.Context, param string) (r Response, err error) {
+	r, err = c.doSingleRequest(ctx, "plugin", param)
+	if err == nil {
+		// Plugins may add or remove options
+		c.setSchema(nil)
+	}
+
+	if errors.Is(err, ErrValidation) {
+		if pluginErr := parsePluginError(r); pluginErr != nil {
+			err = fmt.Errorf("%w: %w", pluginErr, err)
+		}
+	}
+
+	return r, err
+}
+
+// Returns the plugin error matching the Hyprland response, if any. Hyprland
+// returns its last plugin error as text, e.g.: 'error in loading plugin, last
+// error: Cannot load a plugin twice!'.
+func parsePluginError(r Response) error {
+	s := strings.ToLower(string(r))
+
+	switch {
+	case strings.Contains(s, "twice") || strings.Contains(s, "already loaded"):
+		return ErrPluginAlreadyLoaded
+	case strings.Contains(s, "mismatch") || strings.Contains(s, "api version"):
+		return ErrPluginABIMismatch
+	case strings.Contains(s, "not loaded") || strings.Contains(s, "no such file"):
+		return ErrPluginNotFound
+	}
+
+	return nil
+}
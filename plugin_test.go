@@ -0,0 +1,94 @@
+package hyprland_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestPluginLoadUnload(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	c := s.Client()
+
+	s.SetResponse("plugin", "ok")
+
+	r, err := c.PluginLoad("/plugins/hyprbars.so")
+	assert.NoError(t, err)
+	assert.Equal(t, r, "ok")
+
+	_, err = c.PluginUnload("/plugins/hyprbars.so")
+	assert.NoError(t, err)
+
+	assert.Equal(t, s.Commands()[0].Raw, "plugin load /plugins/hyprbars.so")
+	assert.Equal(t, s.Commands()[1].Raw, "plugin unload /plugins/hyprbars.so")
+
+	tests := []struct {
+		response string
+		want     error
+	}{
+		{"error in loading plugin, last error: Cannot load a plugin twice!", hyprland.ErrPluginAlreadyLoaded},
+		{
+			"error in loading plugin, last error: /plugins/foo.so: cannot open shared object file: No such file or directory",
+			hyprland.ErrPluginNotFound,
+		},
+		{"error in loading plugin, last error: API version mismatch", hyprland.ErrPluginABIMismatch},
+		{
+			"error in loading plugin, last error: Version mismatch (headers ver is not equal to running hyprland ver)",
+			hyprland.ErrPluginABIMismatch,
+		},
+		{"plugin not loaded", hyprland.ErrPluginNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.response, func(t *testing.T) {
+			s.SetResponse("plugin", tt.response)
+
+			_, err := c.PluginLoad("/plugins/foo.so")
+			assert.True(t, errors.Is(err, hyprland.ErrValidation))
+			assert.True(t, errors.Is(err, tt.want))
+		})
+	}
+
+	// Unknown errors are only validation errors
+	s.SetResponse("plugin", "error in loading plugin, last error: Plugin crashed in init")
+
+	_, err = c.PluginLoad("/plugins/foo.so")
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+
+	for _, pluginErr := range []error{
+		hyprland.ErrPluginAlreadyLoaded,
+		hyprland.ErrPluginNotFound,
+		hyprland.ErrPluginABIMismatch,
+	} {
+		assert.False(t, errors.Is(err, pluginErr))
+	}
+}
+
+func TestPluginList(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	c := s.Client()
+
+	s.SetResponse("plugin", `[{"name": "hyprbars", "author": "Vaxry", "handle": "5611d4d0a6b0", "version": "1.0", "description": "Title bars"}]`)
+
+	got, err := c.PluginList()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, []hyprland.Plugin{{
+		Name:        "hyprbars",
+		Author:      "Vaxry",
+		Handle:      "5611d4d0a6b0",
+		Version:     "1.0",
+		Description: "Title bars",
+	}})
+	assert.Equal(t, s.Commands()[0].Raw, "plugin list")
+	assert.True(t, s.Commands()[0].JSON)
+
+	for _, response := range []string{"[]", "no plugins loaded"} {
+		s.SetResponse("plugin", response)
+
+		got, err = c.PluginList()
+		assert.NoError(t, err)
+		assert.Equal(t, len(got), 0)
+	}
+}
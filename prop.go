@@ -0,0 +1,357 @@
+package hyprland
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Returned when a window property is unknown or its value has the wrong
+// type. Use [errors.Is] to compare the errors returned with this type.
+var ErrInvalidProp = errors.New("invalid window property")
+
+// WindowProp is a window property that can be changed at runtime, see
+// [RequestClient.SetProp] and https://wiki.hyprland.org/Configuring/Using-hyprctl/#setprop.
+type WindowProp string
+
+// PropType is the value type of a [WindowProp].
+type PropType int
+
+const (
+	PropBool    PropType = iota // bool
+	PropInt                     // int
+	PropFloat                   // float64
+	PropColor                   // Color or Gradient, returned as Gradient
+	PropVec2                    // Vec2
+	PropUnknown                 // string, passed as-is
+)
+
+const (
+	PropAlpha                   WindowProp = "alpha"
+	PropAlphaOverride           WindowProp = "alphaoverride"
+	PropAlphaInactive           WindowProp = "alphainactive"
+	PropAlphaInactiveOverride   WindowProp = "alphainactiveoverride"
+	PropAlphaFullscreen         WindowProp = "alphafullscreen"
+	PropAlphaFullscreenOverride WindowProp = "alphafullscreenoverride"
+	PropActiveBorderColor       WindowProp = "activebordercolor"
+	PropInactiveBorderColor     WindowProp = "inactivebordercolor"
+	PropMaxSize                 WindowProp = "maxsize"
+	PropMinSize                 WindowProp = "minsize"
+	PropRounding                WindowProp = "rounding"
+	PropRoundingPower           WindowProp = "roundingpower"
+	PropBorderSize              WindowProp = "bordersize"
+	PropScrollMouse             WindowProp = "scrollmouse"
+	PropScrollTouchpad          WindowProp = "scrolltouchpad"
+	PropAllowsInput             WindowProp = "allowsinput"
+	PropDimAround               WindowProp = "dimaround"
+	PropDecorate                WindowProp = "decorate"
+	PropFocusOnActivate         WindowProp = "focusonactivate"
+	PropKeepAspectRatio         WindowProp = "keepaspectratio"
+	PropNearestNeighbor         WindowProp = "nearestneighbor"
+	PropNoAnim                  WindowProp = "noanim"
+	PropNoBlur                  WindowProp = "noblur"
+	PropNoBorder                WindowProp = "noborder"
+	PropNoDim                   WindowProp = "nodim"
+	PropNoFocus                 WindowProp = "nofocus"
+	PropNoMaxSize               WindowProp = "nomaxsize"
+	PropNoRounding              WindowProp = "norounding"
+	PropNoShadow                WindowProp = "noshadow"
+	PropNoShortcutsInhibit      WindowProp = "noshortcutsinhibit"
+	PropOpaque                  WindowProp = "opaque"
+	PropForceRGBX               WindowProp = "forcergbx"
+	PropSyncFullscreen          WindowProp = "syncfullscreen"
+	PropImmediate               WindowProp = "immediate"
+	PropXray                    WindowProp = "xray"
+	PropRenderUnfocused         WindowProp = "renderunfocused"
+)
+
+// Same properties as the dynamic window rules.
+var propTypes = map[WindowProp]PropType{
+	PropAlpha:                   PropFloat,
+	PropAlphaOverride:           PropBool,
+	PropAlphaInactive:           PropFloat,
+	PropAlphaInactiveOverride:   PropBool,
+	PropAlphaFullscreen:         PropFloat,
+	PropAlphaFullscreenOverride: PropBool,
+	PropActiveBorderColor:       PropColor,
+	PropInactiveBorderColor:     PropColor,
+	PropMaxSize:                 PropVec2,
+	PropMinSize:                 PropVec2,
+	PropRounding:                PropInt,
+	PropRoundingPower:           PropFloat,
+	PropBorderSize:              PropInt,
+	PropScrollMouse:             PropFloat,
+	PropScrollTouchpad:          PropFloat,
+	PropAllowsInput:             PropBool,
+	PropDimAround:               PropBool,
+	PropDecorate:                PropBool,
+	PropFocusOnActivate:         PropBool,
+	PropKeepAspectRatio:         PropBool,
+	PropNearestNeighbor:         PropBool,
+	PropNoAnim:                  PropBool,
+	PropNoBlur:                  PropBool,
+	PropNoBorder:                PropBool,
+	PropNoDim:                   PropBool,
+	PropNoFocus:                 PropBool,
+	PropNoMaxSize:               PropBool,
+	PropNoRounding:              PropBool,
+	PropNoShadow:                PropBool,
+	PropNoShortcutsInhibit:      PropBool,
+	PropOpaque:                  PropBool,
+	PropForceRGBX:               PropBool,
+	PropSyncFullscreen:          PropBool,
+	PropImmediate:               PropBool,
+	PropXray:                    PropBool,
+	PropRenderUnfocused:         PropBool,
+}
+
+// Type returns the value type of the property, or [PropUnknown] if the
+// property is not in the catalogue (e.g.: from a newer Hyprland version).
+func (p WindowProp) Type() PropType {
+	if t, ok := propTypes[p]; ok {
+		return t
+	}
+
+	return PropUnknown
+}
+
+// FormatValue formats the value in the format expected by setprop,
+// returning an error wrapping [ErrInvalidProp] if the value does not match
+// the property type, see [PropType].
+//
+//nolint:cyclop
+func (p WindowProp) FormatValue(value any) (string, error) {
+	var (
+		s  string
+		ok bool
+	)
+
+	switch p.Type() {
+	case PropBool:
+		var b bool
+		if b, ok = value.(bool); ok {
+			s = boolArg(b)
+		}
+	case PropInt:
+		var i int
+		if i, ok = value.(int); ok {
+			s = strconv.Itoa(i)
+		}
+	case PropFloat:
+		var f float64
+		if f, ok = value.(float64); ok {
+			s = formatFloat(f)
+		}
+	case PropColor:
+		switch v := value.(type) {
+		case Color:
+			s, ok = v.String(), true
+		case Gradient:
+			s, ok = v.String(), true
+		}
+	case PropVec2:
+		var v Vec2
+		if v, ok = value.(Vec2); ok {
+			s = v.String()
+		}
+	case PropUnknown:
+		s, ok = value.(string)
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: value %v (%T) for property %s", ErrInvalidProp, value, value, p)
+	}
+
+	return s, nil
+}
+
+// ParseValue parses a value returned by getprop, returning it with the
+// type of the property, see [PropType].
+func (p WindowProp) ParseValue(raw json.RawMessage) (any, error) {
+	var err error
+
+	switch p.Type() {
+	case PropBool:
+		var b bool
+		err = json.Unmarshal(raw, &b)
+
+		return b, err
+	case PropInt:
+		var i int
+		err = json.Unmarshal(raw, &i)
+
+		return i, err
+	case PropFloat:
+		var f float64
+		err = json.Unmarshal(raw, &f)
+
+		return f, err
+	case PropColor:
+		var s string
+		if err = json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+
+		return ParseGradient(s)
+	case PropVec2:
+		var v []float64
+		if err = json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+
+		if len(v) != 2 {
+			return nil, fmt.Errorf("%w: vec2 %s", ErrInvalidValue, raw)
+		}
+
+		return Vec2{v[0], v[1]}, nil
+	default:
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			s = string(raw)
+		}
+
+		return s, nil
+	}
+}
+
+// PropUpdate is a single setprop command, created by [NewPropUpdate] and sent
+// with [RequestClient.SetProps].
+type PropUpdate struct {
+	window WindowSelector
+	prop   WindowProp
+	value  string
+	lock   bool
+	err    error
+}
+
+// NewPropUpdate creates an update that sets the window property to value,
+// that must have the type of the property, see [PropType].
+// Since setprop arguments are separated by spaces, the window selector can
+// not have spaces, so prefer [WindowByAddress] or [WindowByPid].
+func NewPropUpdate(w WindowSelector, prop WindowProp, value any) PropUpdate {
+	s, err := prop.FormatValue(value)
+	if err == nil {
+		err = validatePropWindow(w)
+	}
+
+	return PropUpdate{window: w, prop: prop, value: s, err: err}
+}
+
+// Lock returns the update with the property locked, i.e.: it will not be
+// changed by window rules later.
+func (u PropUpdate) Lock() PropUpdate {
+	u.lock = true
+
+	return u
+}
+
+// Validate returns the error from [NewPropUpdate], if any.
+func (u PropUpdate) Validate() error {
+	return u.err
+}
+
+// String returns the update in the format expected by setprop, e.g.:
+// 'address:0x1234 alpha 0.5 lock'.
+func (u PropUpdate) String() string {
+	s := joinArgs(" ", string(u.window), string(u.prop), u.value)
+	if u.lock {
+		s += " lock"
+	}
+
+	return s
+}
+
+// Set prop command, similar to 'hyprctl setprop'.
+// Sets a property of a window, see [NewPropUpdate].
+// Returns a [Response], that may be useful for further validations.
+func (c *RequestClient) SetProp(w WindowSelector, prop WindowProp, value any) (r Response, err error) {
+	return c.SetPropContext(context.Background(), w, prop, value)
+}
+
+// SetPropContext is like [RequestClient.SetProp], but with a context.
+func (c *RequestClient) SetPropContext(ctx context.Context, w WindowSelector, prop WindowProp, value any) (r Response, err error) {
+	response, err := c.SetPropsContext(ctx, NewPropUpdate(w, prop, value))
+	if err != nil {
+		return r, err
+	}
+
+	return response[0], nil
+}
+
+// Set props command, sends multiple setprop commands in batch mode.
+// Returns a [Response] list for each update, that may be useful for further
+// validations.
+func (c *RequestClient) SetProps(updates ...PropUpdate) (r []Response, err error) {
+	return c.SetPropsContext(context.Background(), updates...)
+}
+
+// SetPropsContext is like [RequestClient.SetProps], but with a context.
+func (c *RequestClient) SetPropsContext(ctx context.Context, updates ...PropUpdate) (r []Response, err error) {
+	params := make([]string, 0, len(updates))
+
+	for i, u := range updates {
+		if u.err != nil {
+			err = errors.Join(err, fmt.Errorf("update %d ('%s'): %w", i, u, u.err))
+		}
+
+		params = append(params, u.String())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := c.doRequest(ctx, "setprop", params, false)
+	if err != nil {
+		return r, err
+	}
+
+	return parseAndValidateResponse(params, raw)
+}
+
+// Get prop command, similar to 'hyprctl getprop'.
+// Returns the current value of a window property, with the type of the
+// property, see [PropType].
+func (c *RequestClient) GetProp(w WindowSelector, prop WindowProp) (v any, err error) {
+	return c.GetPropContext(context.Background(), w, prop)
+}
+
+// GetPropContext is like [RequestClient.GetProp], but with a context.
+func (c *RequestClient) GetPropContext(ctx context.Context, w WindowSelector, prop WindowProp) (v any, err error) {
+	if err := validatePropWindow(w); err != nil {
+		return nil, err
+	}
+
+	response, err := c.doRequest(ctx, "getprop", []string{joinArgs(" ", string(w), string(prop))}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Errors are returned as text, e.g.: 'window not found'
+	if !json.Valid(response) {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, strings.TrimSpace(string(response)))
+	}
+
+	var values map[string]json.RawMessage
+	if _, err := unmarshalResponse(response, &values); err != nil {
+		return nil, err
+	}
+
+	raw, ok := values[string(prop)]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing property %s in response: %s", ErrValidation, prop, response)
+	}
+
+	return prop.ParseValue(raw)
+}
+
+func validatePropWindow(w WindowSelector) error {
+	if strings.Contains(string(w), " ") {
+		return selectorErr("window", string(w), "spaces are not supported in getprop/setprop")
+	}
+
+	return w.Validate()
+}
@@ -0,0 +1,143 @@
+package hyprland_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestPropUpdate(t *testing.T) {
+	w := hyprland.WindowByAddress("0x1234")
+
+	tests := []struct {
+		update hyprland.PropUpdate
+		want   string
+	}{
+		{hyprland.NewPropUpdate(w, hyprland.PropAlpha, 0.5), "address:0x1234 alpha 0.5"},
+		{hyprland.NewPropUpdate(w, hyprland.PropNoFocus, true).Lock(), "address:0x1234 nofocus 1 lock"},
+		{hyprland.NewPropUpdate(w, hyprland.PropRounding, 10), "address:0x1234 rounding 10"},
+		{hyprland.NewPropUpdate(w, hyprland.PropMaxSize, hyprland.Vec2{X: 800, Y: 600}), "address:0x1234 maxsize 800 600"},
+		{
+			hyprland.NewPropUpdate(w, hyprland.PropActiveBorderColor, hyprland.RGB(0xff, 0, 0)),
+			"address:0x1234 activebordercolor rgba(ff0000ff)",
+		},
+		{
+			hyprland.NewPropUpdate(w, hyprland.PropInactiveBorderColor, hyprland.Gradient{
+				Colors: []hyprland.Color{hyprland.RGB(0xff, 0, 0), hyprland.RGB(0, 0xff, 0)},
+				Angle:  45,
+			}),
+			"address:0x1234 inactivebordercolor rgba(ff0000ff) rgba(00ff00ff) 45deg",
+		},
+		{hyprland.NewPropUpdate(w, "newprop", "foo"), "address:0x1234 newprop foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.NoError(t, tt.update.Validate())
+			assert.Equal(t, tt.update.String(), tt.want)
+		})
+	}
+
+	// Wrong value types
+	for _, u := range []hyprland.PropUpdate{
+		hyprland.NewPropUpdate(w, hyprland.PropAlpha, 1),
+		hyprland.NewPropUpdate(w, hyprland.PropNoFocus, "1"),
+		hyprland.NewPropUpdate(w, hyprland.PropMaxSize, []int{800, 600}),
+		hyprland.NewPropUpdate(w, "newprop", 1),
+	} {
+		assert.True(t, errors.Is(u.Validate(), hyprland.ErrInvalidProp))
+	}
+
+	// Invalid selectors
+	for _, w := range []hyprland.WindowSelector{"", "title:foo bar"} {
+		err := hyprland.NewPropUpdate(w, hyprland.PropAlpha, 0.5).Validate()
+		assert.True(t, errors.Is(err, hyprland.ErrInvalidSelector))
+	}
+}
+
+func TestSetProps(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	c := s.Client()
+	w := hyprland.WindowByAddress("0x1234")
+
+	r, err := c.SetProp(w, hyprland.PropAlpha, 0.8)
+	assert.NoError(t, err)
+	assert.Equal(t, r, "ok")
+
+	_, err = c.SetProps(
+		hyprland.NewPropUpdate(w, hyprland.PropNoBlur, true),
+		hyprland.NewPropUpdate(w, hyprland.PropBorderSize, 0).Lock(),
+	)
+	assert.NoError(t, err)
+
+	var got []string
+	for _, cmd := range s.Commands() {
+		got = append(got, cmd.Raw)
+	}
+
+	assert.DeepEqual(t, got, []string{
+		"setprop address:0x1234 alpha 0.8",
+		"setprop address:0x1234 noblur 1",
+		"setprop address:0x1234 bordersize 0 lock",
+	})
+	// Batched in a single request
+	assert.Equal(t, len(s.Requests()), 2)
+
+	// Invalid updates are not sent
+	_, err = c.SetProps(
+		hyprland.NewPropUpdate(w, hyprland.PropNoBlur, true),
+		hyprland.NewPropUpdate(w, hyprland.PropAlpha, "0.5"),
+	)
+	assert.True(t, errors.Is(err, hyprland.ErrInvalidProp))
+	assert.Equal(t, len(s.Requests()), 2)
+
+	s.SetResponse("setprop", "window not found")
+
+	_, err = c.SetProp(w, hyprland.PropAlpha, 0.8)
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+}
+
+func TestGetProp(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	c := s.Client()
+	w := hyprland.WindowByAddress("0x1234")
+
+	tests := []struct {
+		prop     hyprland.WindowProp
+		response string
+		want     any
+	}{
+		{hyprland.PropAlpha, `{"alpha": 0.5}`, 0.5},
+		{hyprland.PropNoFocus, `{"nofocus": true}`, true},
+		{hyprland.PropRounding, `{"rounding": 10}`, 10},
+		{hyprland.PropMaxSize, `{"maxsize": [800,600]}`, hyprland.Vec2{X: 800, Y: 600}},
+		{
+			hyprland.PropActiveBorderColor,
+			`{"activebordercolor": "ee33ccff ee00ff99 45deg"}`,
+			hyprland.Gradient{
+				Colors: []hyprland.Color{hyprland.RGBA(0x33, 0xcc, 0xff, 0xee), hyprland.RGBA(0x00, 0xff, 0x99, 0xee)},
+				Angle:  45,
+			},
+		},
+		{"newprop", `{"newprop": "foo"}`, "foo"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.prop), func(t *testing.T) {
+			s.SetResponse("getprop", tt.response)
+
+			got, err := c.GetProp(w, tt.prop)
+			assert.NoError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+
+	assert.Equal(t, s.Commands()[0].Raw, "getprop address:0x1234 alpha")
+	assert.True(t, s.Commands()[0].JSON)
+
+	s.SetResponse("getprop", "window not found")
+
+	_, err := c.GetProp(w, hyprland.PropAlpha)
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+}
@@ -0,0 +1,187 @@
+package hyprland
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Query is a JSON request that can be sent together with other queries in a
+// single connection using [RequestClient.Query].
+type Query struct {
+	command string
+	v       any
+}
+
+// NewQuery creates a new [Query] for an arbitrary command that returns a JSON
+// response, e.g.: 'NewQuery("clients", &clients)'. The response will be
+// unmarshalled in v.
+func NewQuery[T any](command string, v *T) Query {
+	return Query{command: command, v: v}
+}
+
+// Active window query, similar to [RequestClient.ActiveWindow].
+func ActiveWindowQuery(w *Window) Query { return NewQuery("activewindow", w) }
+
+// Active workspace query, similar to [RequestClient.ActiveWorkspace].
+func ActiveWorkspaceQuery(w *Workspace) Query { return NewQuery("activeworkspace", w) }
+
+// Clients query, similar to [RequestClient.Clients].
+func ClientsQuery(cl *[]Client) Query { return NewQuery("clients", cl) }
+
+// Cursor position query, similar to [RequestClient.CursorPos].
+func CursorPosQuery(cu *CursorPos) Query { return NewQuery("cursorpos", cu) }
+
+// Descriptions query, similar to [RequestClient.Descriptions].
+func DescriptionsQuery(d *[]Description) Query { return NewQuery("descriptions", d) }
+
+// Get option query, similar to [RequestClient.GetOption].
+func GetOptionQuery(name string, o *Option) Query { return NewQuery("getoption "+name, o) }
+
+// Monitors query, similar to [RequestClient.Monitors].
+func MonitorsQuery(m *[]Monitor) Query { return NewQuery("monitors all", m) }
+
+// Workspaces query, similar to [RequestClient.Workspaces].
+func WorkspacesQuery(w *[]Workspace) Query { return NewQuery("workspaces", w) }
+
+// Command returns the query command, e.g.: 'clients'.
+func (q Query) Command() string { return q.command }
+
+// Query sends multiple queries in a single batch request, unmarshalling each
+// response in its respective value.
+//
+// Hyprland closes the socket after each reply, and an idle connection blocks
+// Hyprland's main loop while it waits for the request, so connections can
+// neither be reused nor pooled. Instead, this method reduces the number of
+// connections by sending the queries in batch mode, e.g.: a status bar that
+// polls clients, workspaces and monitors can do it in a single connection
+// instead of three:
+//
+//	var (
+//		clients    []hyprland.Client
+//		workspaces []hyprland.Workspace
+//		monitors   []hyprland.Monitor
+//	)
+//	err := c.Query(
+//		hyprland.ClientsQuery(&clients),
+//		hyprland.WorkspacesQuery(&workspaces),
+//		hyprland.MonitorsQuery(&monitors),
+//	)
+func (c *RequestClient) Query(queries ...Query) error {
+	return c.QueryContext(context.Background(), queries...)
+}
+
+// QueryContext is like [RequestClient.Query], but with a context.
+func (c *RequestClient) QueryContext(ctx context.Context, queries ...Query) error {
+	if len(queries) == 0 {
+		return ErrEmptyRequest
+	}
+
+	requests, err := prepareQueries(queries)
+	if err != nil {
+		return fmt.Errorf("error while preparing request: %w", err)
+	}
+
+	response, err := c.doRawRequests(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalQueries(response, queries)
+}
+
+// Prepares the batch requests for queries, similar to prepareRequests. The
+// queries are split in multiple requests if they do not fit in the socket
+// buffer, and since the responses are decoded in sequence this is transparent
+// to the caller.
+func prepareQueries(queries []Query) (requests []RawRequest, err error) {
+	buf := bytes.NewBufferString(batch)
+
+	for _, q := range queries {
+		cmdLen := len(jsonReqHeader) + len(q.command) + 1
+
+		// The query will not fit the socket even if sent alone
+		if len(batch)+cmdLen > bufSize {
+			return nil, fmt.Errorf(
+				"%w (%d>%d): %s",
+				ErrCommandTooLong,
+				len(batch)+cmdLen,
+				bufSize,
+				q.command,
+			)
+		}
+
+		if buf.Len()+cmdLen > bufSize {
+			requests = append(requests, bytes.Clone(buf.Bytes()))
+
+			buf.Reset()
+			buf.WriteString(batch)
+		}
+
+		buf.Write(jsonReqHeader)
+		buf.WriteString(q.command)
+		buf.WriteByte(reqSep[1])
+	}
+
+	return append(requests, buf.Bytes()), nil
+}
+
+// Maximum length of a response included in an error message.
+const maxErrResponseLen = 100
+
+// Unmarshals each query from its response slot. A slot that fails does not
+// stop the others from being decoded, and the returned error joins one error
+// for each failed query, including only its own slot.
+func unmarshalQueries(response RawResponse, queries []Query) (err error) {
+	if len(bytes.TrimSpace(response)) == 0 {
+		return ErrEmptyResponse
+	}
+
+	rest := []byte(response)
+
+	for _, q := range queries {
+		decoder := json.NewDecoder(bytes.NewReader(rest))
+
+		decodeErr := decoder.Decode(q.v)
+		if decodeErr == nil {
+			rest = rest[decoder.InputOffset():]
+
+			continue
+		}
+
+		// Responses are separated by an empty line, so skip to the
+		// next one
+		slot, next, _ := bytes.Cut(bytes.TrimLeft(rest, " \t\r\n"), []byte("\n\n"))
+		rest = next
+
+		switch {
+		case errors.Is(decodeErr, io.EOF):
+			decodeErr = io.ErrUnexpectedEOF
+		case !json.Valid(slot):
+			// Hyprland replies errors as text, e.g.: 'no such option'
+			decodeErr = ErrValidation
+		}
+
+		err = errors.Join(err, fmt.Errorf(
+			"error while unmarshal query '%s': %w, response: %s",
+			q.command,
+			decodeErr,
+			truncateResponse(slot),
+		))
+	}
+
+	return err
+}
+
+// Truncates a response to be included in an error message.
+func truncateResponse(response []byte) string {
+	response = bytes.TrimSpace(response)
+	if len(response) > maxErrResponseLen {
+		return string(response[:maxErrResponseLen]) + "..."
+	}
+
+	return string(response)
+}
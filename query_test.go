@@ -0,0 +1,90 @@
+package hyprland
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestUnmarshalQueries(t *testing.T) {
+	var (
+		cl []Client
+		w  Window
+		m  []Monitor
+	)
+
+	response := RawResponse(`[{"address": "0x1"}]` + "\n\n" + `{"address": "0x2"}` + "\n\n" + `[]` + "\n\n")
+
+	err := unmarshalQueries(response, []Query{ClientsQuery(&cl), ActiveWindowQuery(&w), MonitorsQuery(&m)})
+	assert.NoError(t, err)
+	assert.DeepEqual(t, cl, []Client{{Address: "0x1"}})
+	assert.Equal(t, w.Address, "0x2")
+	assert.DeepEqual(t, m, []Monitor{})
+
+	err = unmarshalQueries(RawResponse(`[]`), []Query{ClientsQuery(&cl), MonitorsQuery(&m)})
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	err = unmarshalQueries(RawResponse("unknown request\n\n"), []Query{ClientsQuery(&cl)})
+	assert.Error(t, err)
+
+	err = unmarshalQueries(RawResponse(""), []Query{ClientsQuery(&cl)})
+	assert.True(t, errors.Is(err, ErrEmptyResponse))
+
+	// Only the failed slots are reported, and the others are still decoded
+	cl, m = nil, nil
+	big := `[` + strings.Repeat(`{"address": "0x1"},`, 100) + `{}]`
+	response = RawResponse(big + "\n\n" + "no such option\n\n" + `[{"name": "DP-1"}]` + "\n\n" + "unknown request\n\n")
+
+	err = unmarshalQueries(response, []Query{ClientsQuery(&cl), NewQuery("getoption foo", &w), MonitorsQuery(&m), ClientsQuery(&cl)})
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "'getoption foo'"))
+	assert.True(t, strings.Contains(err.Error(), "response: no such option"))
+	assert.True(t, strings.Contains(err.Error(), "response: unknown request"))
+	assert.False(t, strings.Contains(err.Error(), "0x1"))
+	assert.DeepEqual(t, m, []Monitor{{Name: "DP-1"}})
+}
+
+func TestPrepareQueries(t *testing.T) {
+	queries := make([]Query, 1000)
+	for i := range queries {
+		queries[i] = NewQuery(fmt.Sprintf("getoption option%d", i), &Option{})
+	}
+
+	requests, err := prepareQueries(queries)
+	assert.NoError(t, err)
+	assert.Greater(t, len(requests), 1)
+
+	var got int
+
+	for _, r := range requests {
+		assert.True(t, len(r) <= bufSize)
+		assert.True(t, strings.HasPrefix(string(r), batch))
+		got += strings.Count(string(r), ";")
+	}
+
+	assert.Equal(t, got, len(queries))
+
+	_, err = prepareQueries([]Query{NewQuery(strings.Repeat("c", bufSize), &Option{})})
+	assert.True(t, errors.Is(err, ErrCommandTooLong))
+}
+
+func TestQuery(t *testing.T) {
+	checkEnvironment(t)
+
+	var (
+		cl []Client
+		w  []Workspace
+		m  []Monitor
+	)
+
+	err := c.Query(ClientsQuery(&cl), WorkspacesQuery(&w), MonitorsQuery(&m))
+	assert.NoError(t, err)
+	assert.DeepNotEqual(t, w, []Workspace{})
+	assert.DeepNotEqual(t, m, []Monitor{})
+
+	assert.True(t, errors.Is(c.Query(), ErrEmptyRequest))
+}
@@ -3,12 +3,15 @@ package hyprland
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/thiagokokada/hyprland-go/helpers"
 	"github.com/thiagokokada/hyprland-go/internal/assert"
@@ -25,6 +28,16 @@ var (
 	// Returned when the request is too big, e.g., would not fit the
 	// request buffer.
 	ErrRequestTooBig = errors.New("request too big")
+	// Returned when the response is bigger than the maximum response
+	// size, see [WithMaxResponseSize].
+	ErrResponseTooBig = errors.New("response too big")
+)
+
+const (
+	// Default timeout for each request, see [WithTimeout].
+	DefaultTimeout = 30 * time.Second
+	// Default maximum size of each response, see [WithMaxResponseSize].
+	DefaultMaxResponseSize = 64 << 20 // 64 MiB
 )
 
 // Initiate a new client or panic.
@@ -33,22 +46,53 @@ var (
 // HYPRLAND_INSTANCE_SIGNATURE for the current user.
 // If you need to connect to arbitrary user instances or need a method that
 // will not panic on error, use [NewClient] instead.
-func MustClient() *RequestClient {
+// Each request times out after [DefaultTimeout], see [NewClient].
+func MustClient(opts ...ClientOption) *RequestClient {
 	return NewClient(
 		assert.Must1(helpers.GetSocket(helpers.RequestSocket)),
+		opts...,
 	)
 }
 
 // Initiate a new client.
 // Receive as parameters a requestSocket that is generally localised in
 // '$XDG_RUNTIME_DIR/hypr/$HYPRLAND_INSTANCE_SIGNATURE/.socket.sock'.
-func NewClient(socket string) *RequestClient {
-	return &RequestClient{
+// Unless [WithTimeout] is passed, each request times out after
+// [DefaultTimeout], so requests that take longer (e.g.: a dispatcher that waits
+// for user input) fail with an error wrapping [context.DeadlineExceeded].
+// Previous versions had no timeout, use WithTimeout(0) to keep this behaviour.
+func NewClient(socket string, opts ...ClientOption) *RequestClient {
+	c := &RequestClient{
 		conn: &net.UnixAddr{
 			Net:  "unix",
 			Name: socket,
 		},
+		timeout:         DefaultTimeout,
+		maxResponseSize: DefaultMaxResponseSize,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ClientOption configures a [RequestClient], see [NewClient].
+type ClientOption func(c *RequestClient)
+
+// WithTimeout sets the maximum duration of each request, including the
+// dial, write and read. A zero or negative duration disables the timeout.
+// The default is [DefaultTimeout].
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *RequestClient) { c.timeout = d }
+}
+
+// WithMaxResponseSize sets the maximum size in bytes of each response.
+// Bigger responses will return [ErrResponseTooBig].
+// The default is [DefaultMaxResponseSize].
+func WithMaxResponseSize(size int64) ClientOption {
+	return func(c *RequestClient) { c.maxResponseSize = size }
 }
 
 // Low-level request method, should be avoided unless there is no alternative.
@@ -58,14 +102,43 @@ func NewClient(socket string) *RequestClient {
 // Keep in mind that there is no validation. In case of an invalid request, the
 // response will generally be something different from "ok".
 func (c *RequestClient) RawRequest(request RawRequest) (response RawResponse, err error) {
+	return c.RawRequestContext(context.Background(), request)
+}
+
+// RawRequestContext is like [RequestClient.RawRequest], but with a context.
+// The context deadline is applied to the dial, write and read of the request,
+// and cancelling the context will interrupt the request. In both cases, the
+// returned error wraps [context.Context.Err].
+// The response is read until Hyprland closes the connection, limited by the
+// client timeout and maximum response size.
+func (c *RequestClient) RawRequestContext(ctx context.Context, request RawRequest) (response RawResponse, err error) {
 	if len(request) == 0 {
 		return nil, ErrEmptyRequest
 	}
 
+	if len(request) > bufSize {
+		return nil, fmt.Errorf(
+			"%w (%d>%d): %s",
+			ErrRequestTooBig,
+			len(request),
+			bufSize,
+			request,
+		)
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	// Connect to the request socket
-	conn, err := net.DialUnix("unix", nil, c.conn)
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "unix", c.conn.Name)
 	if err != nil {
-		return nil, fmt.Errorf("error while connecting to socket: %w", err)
+		return nil, contextError(ctx, fmt.Errorf("error while connecting to socket: %w", err))
 	}
 
 	defer func() {
@@ -74,58 +147,60 @@ func (c *RequestClient) RawRequest(request RawRequest) (response RawResponse, er
 		}
 	}()
 
-	if len(request) > bufSize {
-		return nil, fmt.Errorf(
-			"%w (%d>%d): %s",
-			ErrRequestTooBig,
-			len(request),
-			bufSize,
-			request,
-		)
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("error while setting deadline: %w", err)
+		}
 	}
 
+	// Set a deadline in the past to unblock any pending read or write
+	// once the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Unix(1, 0)) //nolint:errcheck
+	})
+	defer stop()
+
 	writer := bufio.NewWriter(conn)
 
 	// Send the request to the socket
 	_, err = writer.Write(request)
 	if err != nil {
-		return nil, fmt.Errorf("error while writing to socket: %w", err)
+		return nil, contextError(ctx, fmt.Errorf("error while writing to socket: %w", err))
 	}
 
 	err = writer.Flush()
 	if err != nil {
-		return nil, fmt.Errorf("error while flushing to socket: %w", err)
+		return nil, contextError(ctx, fmt.Errorf("error while flushing to socket: %w", err))
 	}
 
-	// Get the response back
-	rbuf := bytes.NewBuffer(nil)
-	sbuf := make([]byte, bufSize)
-	reader := bufio.NewReader(conn)
-
-	for {
-		n, err := reader.Read(sbuf)
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-
-			return nil, fmt.Errorf("error while reading from socket: %w", err)
-		}
-
-		rbuf.Write(sbuf[:n])
+	// Get the response back. Hyprland closes the connection after the
+	// response is written, so read until EOF
+	response, err = io.ReadAll(io.LimitReader(conn, c.maxResponseSize+1))
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("error while reading from socket: %w", err))
+	}
 
-		if n < bufSize {
-			break
-		}
+	if int64(len(response)) > c.maxResponseSize {
+		return nil, fmt.Errorf(
+			"%w (>%d): %s...",
+			ErrResponseTooBig,
+			c.maxResponseSize,
+			response[:min(len(response), 100)],
+		)
 	}
 
-	return rbuf.Bytes(), err
+	return response, nil
 }
 
 // Active window command, similar to 'hyprctl activewindow'.
 // Returns a [Window] object.
 func (c *RequestClient) ActiveWindow() (w Window, err error) {
-	response, err := c.doRequest("activewindow", nil, true)
+	return c.ActiveWindowContext(context.Background())
+}
+
+// ActiveWindowContext is like [RequestClient.ActiveWindow], but with a context.
+func (c *RequestClient) ActiveWindowContext(ctx context.Context) (w Window, err error) {
+	response, err := c.doRequest(ctx, "activewindow", nil, true)
 	if err != nil {
 		return w, err
 	}
@@ -136,7 +211,12 @@ func (c *RequestClient) ActiveWindow() (w Window, err error) {
 // Get option command, similar to 'hyprctl activeworkspace'.
 // Returns a [Workspace] object.
 func (c *RequestClient) ActiveWorkspace() (w Workspace, err error) {
-	response, err := c.doRequest("activeworkspace", nil, true)
+	return c.ActiveWorkspaceContext(context.Background())
+}
+
+// ActiveWorkspaceContext is like [RequestClient.ActiveWorkspace], but with a context.
+func (c *RequestClient) ActiveWorkspaceContext(ctx context.Context) (w Workspace, err error) {
+	response, err := c.doRequest(ctx, "activeworkspace", nil, true)
 	if err != nil {
 		return w, err
 	}
@@ -147,7 +227,12 @@ func (c *RequestClient) ActiveWorkspace() (w Workspace, err error) {
 // Animations command, similar to 'hyprctl animations'.
 // Returns a [Animation] object.
 func (c *RequestClient) Animations() (a [][]Animation, err error) {
-	response, err := c.doRequest("animations", nil, true)
+	return c.AnimationsContext(context.Background())
+}
+
+// AnimationsContext is like [RequestClient.Animations], but with a context.
+func (c *RequestClient) AnimationsContext(ctx context.Context) (a [][]Animation, err error) {
+	response, err := c.doRequest(ctx, "animations", nil, true)
 	if err != nil {
 		return a, err
 	}
@@ -158,7 +243,12 @@ func (c *RequestClient) Animations() (a [][]Animation, err error) {
 // Binds command, similar to 'hyprctl binds'.
 // Returns a [Bind] object.
 func (c *RequestClient) Binds() (b []Bind, err error) {
-	response, err := c.doRequest("binds", nil, true)
+	return c.BindsContext(context.Background())
+}
+
+// BindsContext is like [RequestClient.Binds], but with a context.
+func (c *RequestClient) BindsContext(ctx context.Context) (b []Bind, err error) {
+	response, err := c.doRequest(ctx, "binds", nil, true)
 	if err != nil {
 		return b, err
 	}
@@ -169,7 +259,12 @@ func (c *RequestClient) Binds() (b []Bind, err error) {
 // Clients command, similar to 'hyprctl clients'.
 // Returns a [Client] object.
 func (c *RequestClient) Clients() (cl []Client, err error) {
-	response, err := c.doRequest("clients", nil, true)
+	return c.ClientsContext(context.Background())
+}
+
+// ClientsContext is like [RequestClient.Clients], but with a context.
+func (c *RequestClient) ClientsContext(ctx context.Context) (cl []Client, err error) {
+	response, err := c.doRequest(ctx, "clients", nil, true)
 	if err != nil {
 		return cl, err
 	}
@@ -180,7 +275,12 @@ func (c *RequestClient) Clients() (cl []Client, err error) {
 // ConfigErrors command, similar to `hyprctl configerrors`.
 // Returns a [ConfigError] object.
 func (c *RequestClient) ConfigErrors() (ce []ConfigError, err error) {
-	response, err := c.doRequest("configerrors", nil, true)
+	return c.ConfigErrorsContext(context.Background())
+}
+
+// ConfigErrorsContext is like [RequestClient.ConfigErrors], but with a context.
+func (c *RequestClient) ConfigErrorsContext(ctx context.Context) (ce []ConfigError, err error) {
+	response, err := c.doRequest(ctx, "configerrors", nil, true)
 	if err != nil {
 		return ce, err
 	}
@@ -191,7 +291,12 @@ func (c *RequestClient) ConfigErrors() (ce []ConfigError, err error) {
 // Cursor position command, similar to 'hyprctl cursorpos'.
 // Returns a [CursorPos] object.
 func (c *RequestClient) CursorPos() (cu CursorPos, err error) {
-	response, err := c.doRequest("cursorpos", nil, true)
+	return c.CursorPosContext(context.Background())
+}
+
+// CursorPosContext is like [RequestClient.CursorPos], but with a context.
+func (c *RequestClient) CursorPosContext(ctx context.Context) (cu CursorPos, err error) {
+	response, err := c.doRequest(ctx, "cursorpos", nil, true)
 	if err != nil {
 		return cu, err
 	}
@@ -202,7 +307,12 @@ func (c *RequestClient) CursorPos() (cu CursorPos, err error) {
 // Decorations command, similar to `hyprctl decorations`.
 // Returns a [Decoration] object.
 func (c *RequestClient) Decorations(regex string) (d []Decoration, err error) {
-	response, err := c.doRequest("decorations", []string{regex}, true)
+	return c.DecorationsContext(context.Background(), regex)
+}
+
+// DecorationsContext is like [RequestClient.Decorations], but with a context.
+func (c *RequestClient) DecorationsContext(ctx context.Context, regex string) (d []Decoration, err error) {
+	response, err := c.doRequest(ctx, "decorations", []string{regex}, true)
 	if err != nil {
 		return d, err
 	}
@@ -215,10 +325,31 @@ func (c *RequestClient) Decorations(regex string) (d []Decoration, err error) {
 	return unmarshalResponse(response, &d)
 }
 
+// Descriptions command, similar to `hyprctl descriptions`.
+// Returns a [Description] object for each configuration option.
+func (c *RequestClient) Descriptions() (d []Description, err error) {
+	return c.DescriptionsContext(context.Background())
+}
+
+// DescriptionsContext is like [RequestClient.Descriptions], but with a context.
+func (c *RequestClient) DescriptionsContext(ctx context.Context) (d []Description, err error) {
+	response, err := c.doRequest(ctx, "descriptions", nil, true)
+	if err != nil {
+		return d, err
+	}
+
+	return unmarshalResponse(response, &d)
+}
+
 // Devices command, similar to `hyprctl devices`.
 // Returns a [Devices] object.
 func (c *RequestClient) Devices() (d Devices, err error) {
-	response, err := c.doRequest("devices", nil, true)
+	return c.DevicesContext(context.Background())
+}
+
+// DevicesContext is like [RequestClient.Devices], but with a context.
+func (c *RequestClient) DevicesContext(ctx context.Context) (d Devices, err error) {
+	response, err := c.doRequest(ctx, "devices", nil, true)
 	if err != nil {
 		return d, err
 	}
@@ -232,7 +363,12 @@ func (c *RequestClient) Devices() (d Devices, err error) {
 // Returns a [Response] list for each parameter, that may be useful for further
 // validations.
 func (c *RequestClient) Dispatch(params ...string) (r []Response, err error) {
-	raw, err := c.doRequest("dispatch", params, false)
+	return c.DispatchContext(context.Background(), params...)
+}
+
+// DispatchContext is like [RequestClient.Dispatch], but with a context.
+func (c *RequestClient) DispatchContext(ctx context.Context, params ...string) (r []Response, err error) {
+	raw, err := c.doRequest(ctx, "dispatch", params, false)
 	if err != nil {
 		return r, err
 	}
@@ -243,7 +379,12 @@ func (c *RequestClient) Dispatch(params ...string) (r []Response, err error) {
 // Get option command, similar to 'hyprctl getoption'.
 // Returns an [Option] object.
 func (c *RequestClient) GetOption(name string) (o Option, err error) {
-	response, err := c.doRequest("getoption", []string{name}, true)
+	return c.GetOptionContext(context.Background(), name)
+}
+
+// GetOptionContext is like [RequestClient.GetOption], but with a context.
+func (c *RequestClient) GetOptionContext(ctx context.Context, name string) (o Option, err error) {
+	response, err := c.doRequest(ctx, "getoption", []string{name}, true)
 	if err != nil {
 		return o, err
 	}
@@ -251,13 +392,34 @@ func (c *RequestClient) GetOption(name string) (o Option, err error) {
 	return unmarshalResponse(response, &o)
 }
 
+// Global shortcuts command, similar to 'hyprctl globalshortcuts'.
+// Returns a [GlobalShortcut] object.
+func (c *RequestClient) GlobalShortcuts() (g []GlobalShortcut, err error) {
+	return c.GlobalShortcutsContext(context.Background())
+}
+
+// GlobalShortcutsContext is like [RequestClient.GlobalShortcuts], but with a context.
+func (c *RequestClient) GlobalShortcutsContext(ctx context.Context) (g []GlobalShortcut, err error) {
+	response, err := c.doRequest(ctx, "globalshortcuts", nil, true)
+	if err != nil {
+		return g, err
+	}
+
+	return unmarshalResponse(response, &g)
+}
+
 // Keyword command, similar to 'hyprctl keyword'.
 // Accept multiple commands at the same time, in this case it will use batch
 // mode, similar to 'hyprctl keyword --batch'.
 // Returns a [Response] list for each parameter, that may be useful for further
 // validations.
 func (c *RequestClient) Keyword(params ...string) (r []Response, err error) {
-	raw, err := c.doRequest("keyword", params, false)
+	return c.KeywordContext(context.Background(), params...)
+}
+
+// KeywordContext is like [RequestClient.Keyword], but with a context.
+func (c *RequestClient) KeywordContext(ctx context.Context, params ...string) (r []Response, err error) {
+	raw, err := c.doRequest(ctx, "keyword", params, false)
 	if err != nil {
 		return r, err
 	}
@@ -270,7 +432,12 @@ func (c *RequestClient) Keyword(params ...string) (r []Response, err error) {
 // user to click in the window.
 // Returns a [Response], that may be useful for further validations.
 func (c *RequestClient) Kill() (r Response, err error) {
-	raw, err := c.doRequest("kill", nil, true)
+	return c.KillContext(context.Background())
+}
+
+// KillContext is like [RequestClient.Kill], but with a context.
+func (c *RequestClient) KillContext(ctx context.Context) (r Response, err error) {
+	raw, err := c.doRequest(ctx, "kill", nil, true)
 	if err != nil {
 		return r, err
 	}
@@ -283,7 +450,28 @@ func (c *RequestClient) Kill() (r Response, err error) {
 // Layer command, similar to 'hyprctl layers'.
 // Returns a [Layer] object.
 func (c *RequestClient) Layers() (l Layers, err error) {
-	response, err := c.doRequest("layers", nil, true)
+	return c.LayersContext(context.Background())
+}
+
+// LayersContext is like [RequestClient.Layers], but with a context.
+func (c *RequestClient) LayersContext(ctx context.Context) (l Layers, err error) {
+	response, err := c.doRequest(ctx, "layers", nil, true)
+	if err != nil {
+		return l, err
+	}
+
+	return unmarshalResponse(response, &l)
+}
+
+// Layouts command, similar to 'hyprctl layouts'.
+// Returns the name of each available layout, e.g.: 'dwindle'.
+func (c *RequestClient) Layouts() (l []string, err error) {
+	return c.LayoutsContext(context.Background())
+}
+
+// LayoutsContext is like [RequestClient.Layouts], but with a context.
+func (c *RequestClient) LayoutsContext(ctx context.Context) (l []string, err error) {
+	response, err := c.doRequest(ctx, "layouts", nil, true)
 	if err != nil {
 		return l, err
 	}
@@ -294,7 +482,12 @@ func (c *RequestClient) Layers() (l Layers, err error) {
 // Monitors command, similar to 'hyprctl monitors'.
 // Returns a [Monitor] object.
 func (c *RequestClient) Monitors() (m []Monitor, err error) {
-	response, err := c.doRequest("monitors all", nil, true)
+	return c.MonitorsContext(context.Background())
+}
+
+// MonitorsContext is like [RequestClient.Monitors], but with a context.
+func (c *RequestClient) MonitorsContext(ctx context.Context) (m []Monitor, err error) {
+	response, err := c.doRequest(ctx, "monitors all", nil, true)
 	if err != nil {
 		return m, err
 	}
@@ -305,7 +498,12 @@ func (c *RequestClient) Monitors() (m []Monitor, err error) {
 // Reload command, similar to 'hyprctl reload'.
 // Returns a [Response], that may be useful for further validations.
 func (c *RequestClient) Reload() (r Response, err error) {
-	raw, err := c.doRequest("reload", nil, false)
+	return c.ReloadContext(context.Background())
+}
+
+// ReloadContext is like [RequestClient.Reload], but with a context.
+func (c *RequestClient) ReloadContext(ctx context.Context) (r Response, err error) {
+	raw, err := c.doRequest(ctx, "reload", nil, false)
 	if err != nil {
 		return r, err
 	}
@@ -315,10 +513,32 @@ func (c *RequestClient) Reload() (r Response, err error) {
 	return response[0], err // should return only one response
 }
 
+// Rolling log command, similar to 'hyprctl rollinglog'.
+// Returns the last lines of the Hyprland log as text.
+func (c *RequestClient) RollingLog() (s string, err error) {
+	return c.RollingLogContext(context.Background())
+}
+
+// RollingLogContext is like [RequestClient.RollingLog], but with a context.
+func (c *RequestClient) RollingLogContext(ctx context.Context) (s string, err error) {
+	// Same as submap, the JSON output is not valid JSON.
+	response, err := c.doRequest(ctx, "rollinglog", nil, false)
+	if err != nil {
+		return s, err
+	}
+
+	return string(response), nil
+}
+
 // Set cursor command, similar to 'hyprctl setcursor'.
 // Returns a [Response], that may be useful for further validations.
 func (c *RequestClient) SetCursor(theme string, size int) (r Response, err error) {
-	raw, err := c.doRequest("setcursor", []string{fmt.Sprintf("%s %d", theme, size)}, false)
+	return c.SetCursorContext(context.Background(), theme, size)
+}
+
+// SetCursorContext is like [RequestClient.SetCursor], but with a context.
+func (c *RequestClient) SetCursorContext(ctx context.Context, theme string, size int) (r Response, err error) {
+	raw, err := c.doRequest(ctx, "setcursor", []string{fmt.Sprintf("%s %d", theme, size)}, false)
 	if err != nil {
 		return r, err
 	}
@@ -332,7 +552,12 @@ func (c *RequestClient) SetCursor(theme string, size int) (r Response, err error
 // Returns a [Response], that may be useful for further validations.
 // Param cmd can be either 'next', 'prev' or an ID (e.g: 0).
 func (c *RequestClient) SwitchXkbLayout(device string, cmd string) (r Response, err error) {
-	raw, err := c.doRequest("switchxkblayout", []string{fmt.Sprintf("%s %s", device, cmd)}, false)
+	return c.SwitchXkbLayoutContext(context.Background(), device, cmd)
+}
+
+// SwitchXkbLayoutContext is like [RequestClient.SwitchXkbLayout], but with a context.
+func (c *RequestClient) SwitchXkbLayoutContext(ctx context.Context, device string, cmd string) (r Response, err error) {
+	raw, err := c.doRequest(ctx, "switchxkblayout", []string{fmt.Sprintf("%s %s", device, cmd)}, false)
 	if err != nil {
 		return r, err
 	}
@@ -344,7 +569,47 @@ func (c *RequestClient) SwitchXkbLayout(device string, cmd string) (r Response,
 
 // Splash command, similar to 'hyprctl splash'.
 func (c *RequestClient) Splash() (s string, err error) {
-	response, err := c.doRequest("splash", nil, false)
+	return c.SplashContext(context.Background())
+}
+
+// SplashContext is like [RequestClient.Splash], but with a context.
+func (c *RequestClient) SplashContext(ctx context.Context) (s string, err error) {
+	response, err := c.doRequest(ctx, "splash", nil, false)
+	if err != nil {
+		return s, err
+	}
+
+	return string(response), nil
+}
+
+// Submap command, similar to 'hyprctl submap'.
+// Returns the name of the current submap, or 'default' if none is active.
+func (c *RequestClient) Submap() (s string, err error) {
+	return c.SubmapContext(context.Background())
+}
+
+// SubmapContext is like [RequestClient.Submap], but with a context.
+func (c *RequestClient) SubmapContext(ctx context.Context) (s string, err error) {
+	// The JSON output of submap is not valid JSON (e.g.: '{"default"}'),
+	// so use the plain text output instead.
+	response, err := c.doRequest(ctx, "submap", nil, false)
+	if err != nil {
+		return s, err
+	}
+
+	return strings.TrimSpace(string(response)), nil
+}
+
+// System info command, similar to 'hyprctl systeminfo'.
+// Returns the same text report that is used in Hyprland bug reports, since
+// there is no JSON output for this command.
+func (c *RequestClient) SystemInfo() (s string, err error) {
+	return c.SystemInfoContext(context.Background())
+}
+
+// SystemInfoContext is like [RequestClient.SystemInfo], but with a context.
+func (c *RequestClient) SystemInfoContext(ctx context.Context) (s string, err error) {
+	response, err := c.doRequest(ctx, "systeminfo", nil, false)
 	if err != nil {
 		return s, err
 	}
@@ -355,7 +620,12 @@ func (c *RequestClient) Splash() (s string, err error) {
 // Version command, similar to 'hyprctl version'.
 // Returns a [Version] object.
 func (c *RequestClient) Version() (v Version, err error) {
-	response, err := c.doRequest("version", nil, true)
+	return c.VersionContext(context.Background())
+}
+
+// VersionContext is like [RequestClient.Version], but with a context.
+func (c *RequestClient) VersionContext(ctx context.Context) (v Version, err error) {
+	response, err := c.doRequest(ctx, "version", nil, true)
 	if err != nil {
 		return v, err
 	}
@@ -363,10 +633,31 @@ func (c *RequestClient) Version() (v Version, err error) {
 	return unmarshalResponse(response, &v)
 }
 
+// Workspace rules command, similar to 'hyprctl workspacerules'.
+// Returns a [WorkspaceRule] object.
+func (c *RequestClient) WorkspaceRules() (w []WorkspaceRule, err error) {
+	return c.WorkspaceRulesContext(context.Background())
+}
+
+// WorkspaceRulesContext is like [RequestClient.WorkspaceRules], but with a context.
+func (c *RequestClient) WorkspaceRulesContext(ctx context.Context) (w []WorkspaceRule, err error) {
+	response, err := c.doRequest(ctx, "workspacerules", nil, true)
+	if err != nil {
+		return w, err
+	}
+
+	return unmarshalResponse(response, &w)
+}
+
 // Workspaces option command, similar to 'hyprctl workspaces'.
 // Returns a [Workspace] object.
 func (c *RequestClient) Workspaces() (w []Workspace, err error) {
-	response, err := c.doRequest("workspaces", nil, true)
+	return c.WorkspacesContext(context.Background())
+}
+
+// WorkspacesContext is like [RequestClient.Workspaces], but with a context.
+func (c *RequestClient) WorkspacesContext(ctx context.Context) (w []Workspace, err error) {
+	response, err := c.doRequest(ctx, "workspaces", nil, true)
 	if err != nil {
 		return w, err
 	}
@@ -468,9 +759,10 @@ func prepareRequests(command string, params []string, jsonResp bool) (requests [
 			// param is bigger than bufSize, we will need to split
 			// the request
 			if curLen+cmdLen > bufSize {
-				// Append current buffer contents to the
-				// requests array
-				requests = append(requests, buf.Bytes())
+				// Append a copy of current buffer contents to
+				// the requests array, since the buffer will be
+				// reused
+				requests = append(requests, bytes.Clone(buf.Bytes()))
 
 				// Reset the current buffer and add [[BATCH]]
 				buf.Reset()
@@ -528,18 +820,25 @@ func validateResponse(params []string, response []Response) ([]Response, error)
 		)
 	}
 
-	// validate that all responses are ok
+	// validate that all responses are ok, reporting every failure
+	var failures []BatchFailure
+
 	for i, r := range response {
 		if r != "ok" {
-			return response, fmt.Errorf(
-				"%w: non-ok response from param: %s, response: %s",
-				ErrValidation,
-				params[i],
-				r,
-			)
+			// commands without parameters have an empty param
+			var param string
+			if i < len(params) {
+				param = params[i]
+			}
+
+			failures = append(failures, BatchFailure{Index: i, Param: param, Response: r})
 		}
 	}
 
+	if len(failures) > 0 {
+		return response, &BatchError{Failures: failures}
+	}
+
 	return response, nil
 }
 
@@ -549,9 +848,45 @@ func parseAndValidateResponse(params []string, raw RawResponse) ([]Response, err
 		return response, err
 	}
 
+	// Some replies have multiple lines (e.g.: config errors), so split them
+	// by the empty line that follows each reply in batch mode instead
+	if len(response) != max(len(params), 1) {
+		if replies := splitReplies(raw); len(replies) == max(len(params), 1) {
+			response = replies
+		}
+	}
+
 	return validateResponse(params, response)
 }
 
+// Splits the replies of a batch request, that are separated by an empty
+// line.
+func splitReplies(raw RawResponse) (replies []Response) {
+	raw = bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))
+
+	for _, r := range bytes.Split(raw, []byte("\n\n")) {
+		if r := strings.TrimSpace(string(r)); r != "" {
+			replies = append(replies, Response(r))
+		}
+	}
+
+	return replies
+}
+
+// Wraps the context error in err, if any.
+func contextError(ctx context.Context, err error) error {
+	// The socket deadline may expire slightly before the context one
+	if deadline, ok := ctx.Deadline(); ok && errors.Is(err, os.ErrDeadlineExceeded) && !time.Now().Before(deadline) {
+		<-ctx.Done()
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return errors.Join(err, ctxErr)
+	}
+
+	return err
+}
+
 func unmarshalResponse[T any](response RawResponse, v *T) (T, error) {
 	if len(response) == 0 {
 		return *v, ErrEmptyResponse
@@ -569,16 +904,26 @@ func unmarshalResponse[T any](response RawResponse, v *T) (T, error) {
 	return *v, nil
 }
 
-func (c *RequestClient) doRequest(command string, params []string, jsonResp bool) (response RawResponse, err error) {
+func (c *RequestClient) doRequest(ctx context.Context, command string, params []string, jsonResp bool) (response RawResponse, err error) {
 	requests, err := prepareRequests(command, params, jsonResp)
 	if err != nil {
 		return nil, fmt.Errorf("error while preparing request: %w", err)
 	}
 
+	return c.doRawRequests(ctx, requests)
+}
+
+// Sends each request in sequence, returning the concatenated responses.
+func (c *RequestClient) doRawRequests(ctx context.Context, requests []RawRequest) (response RawResponse, err error) {
 	buf := bytes.NewBuffer(nil)
 
 	for _, req := range requests {
-		resp, err := c.RawRequest(req)
+		// Avoid sending the remaining chunks if the context is done
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("error while doing request: %w", err)
+		}
+
+		resp, err := c.RawRequestContext(ctx, req)
 		if err != nil {
 			return nil, fmt.Errorf("error while doing request: %w", err)
 		}
@@ -1,11 +1,16 @@
 package hyprland
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -129,6 +134,33 @@ func TestPrepareRequestsMass(t *testing.T) {
 	}
 }
 
+func TestPrepareRequestsSplit(t *testing.T) {
+	// Make sure that each split request is not overwritten by the next
+	// one
+	var params []string
+	for i := 0; i < 1000; i++ {
+		params = append(params, fmt.Sprintf("param%d", i))
+	}
+
+	requests, err := prepareRequests("command", params, true)
+	assert.NoError(t, err)
+	assert.Greater(t, len(requests), 1)
+
+	var got []string
+
+	for _, r := range requests {
+		assert.True(t, strings.HasPrefix(string(r), batch))
+
+		for _, p := range strings.Split(strings.TrimPrefix(string(r), batch), ";") {
+			if p != "" {
+				got = append(got, strings.TrimPrefix(p, "j/command "))
+			}
+		}
+	}
+
+	assert.DeepEqual(t, got, params)
+}
+
 func TestPrepareRequestsError(t *testing.T) {
 	tests := []struct {
 		lastSafeLen int
@@ -223,6 +255,8 @@ func TestValidateResponse(t *testing.T) {
 		{genParams("param", 2), []Response{"ok"}, []Response{"ok"}, true},
 		// non-ok response
 		{genParams("param", 2), []Response{"ok", "Invalid command"}, []Response{"ok", "Invalid command"}, true},
+		// non-ok response, nil param
+		{nil, []Response{"Invalid command"}, []Response{"Invalid command"}, true},
 	}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("tests_%v-%v", tt.params, tt.response), func(t *testing.T) {
@@ -239,12 +273,229 @@ func TestValidateResponse(t *testing.T) {
 	}
 }
 
+func TestValidateResponseBatchError(t *testing.T) {
+	params := genParams("param", 5)
+	response := []Response{"ok", "Invalid dispatcher", "ok", "ok", "Invalid command"}
+
+	_, err := validateResponse(params, response)
+	assert.True(t, errors.Is(err, ErrValidation))
+
+	var batchErr *BatchError
+	assert.True(t, errors.As(err, &batchErr))
+	assert.DeepEqual(t, batchErr.Failures, []BatchFailure{
+		{Index: 1, Param: params[1], Response: "Invalid dispatcher"},
+		{Index: 4, Param: params[4], Response: "Invalid command"},
+	})
+	assert.True(t, strings.Contains(err.Error(), "2 non-ok response(s)"))
+
+	// missing responses are not a batch error
+	_, err = validateResponse(params, response[:2])
+	assert.True(t, errors.Is(err, ErrValidation))
+	assert.False(t, errors.As(err, &batchErr))
+}
+
+func TestParseAndValidateResponseMultiline(t *testing.T) {
+	params := genParams("param", 3)
+	raw := RawResponse("ok\n\nerror in config:\ninvalid value\n\nok\n\n")
+
+	response, err := parseAndValidateResponse(params, raw)
+	assert.DeepEqual(t, response, []Response{"ok", "error in config:\ninvalid value", "ok"})
+
+	var batchErr *BatchError
+	assert.True(t, errors.As(err, &batchErr))
+	assert.DeepEqual(t, batchErr.Failures, []BatchFailure{
+		{Index: 1, Param: params[1], Response: "error in config:\ninvalid value"},
+	})
+
+	// Also with CRLF
+	_, err = parseAndValidateResponse(params, RawResponse("ok\r\n\r\nfoo\r\nbar\r\n\r\nok\r\n\r\n"))
+	assert.True(t, errors.As(err, &batchErr))
+	assert.Equal(t, len(batchErr.Failures), 1)
+
+	// Missing replies are still not a batch error
+	_, err = parseAndValidateResponse(params, RawResponse("ok\n\nfoo\nbar\nbaz\n\n"))
+	assert.True(t, errors.Is(err, ErrValidation))
+	assert.False(t, errors.As(err, &batchErr))
+}
+
 func TestRawRequest(t *testing.T) {
 	testCommand(t, func() (RawResponse, error) {
 		return c.RawRequest([]byte("splash"))
 	}, RawResponse{})
 }
 
+// Returns a temporary dir for sockets, removed at the end of the test.
+// Avoid t.TempDir() since Unix sockets paths are limited to 108 characters,
+// similar to hyprlandtest.
+func testSocketDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "hyprland")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
+
+// Starts a server that accepts connections but never replies, similar to a
+// hung compositor.
+func hungServer(t *testing.T) (socket string, accepted *atomic.Int32) {
+	t.Helper()
+
+	socket = filepath.Join(testSocketDir(t), ".socket.sock")
+	l, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+
+	accepted = &atomic.Int32{}
+	done := make(chan struct{})
+
+	t.Cleanup(func() {
+		l.Close()
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+
+		var conns []net.Conn
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				for _, conn := range conns {
+					conn.Close()
+				}
+
+				return
+			}
+
+			accepted.Add(1)
+
+			conns = append(conns, conn)
+		}
+	}()
+
+	return socket, accepted
+}
+
+func TestRawRequestContext(t *testing.T) {
+	socket, _ := hungServer(t)
+	client := NewClient(socket)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.RawRequestContext(ctx, []byte("splash"))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err = client.ClientsContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestDispatchContextSplitBatch(t *testing.T) {
+	socket, accepted := hungServer(t)
+	client := NewClient(socket)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// No chunk should be sent with an already cancelled context
+	_, err := client.DispatchContext(ctx, genParams("exec kitty", 1000)...)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, accepted.Load(), 0)
+}
+
+// Starts a server that writes the response in small chunks, to make sure the
+// client does not stop reading before the response ends.
+func dribbleServer(t *testing.T, response []byte, chunkSize int) (socket string) {
+	t.Helper()
+
+	socket = filepath.Join(testSocketDir(t), ".socket.sock")
+	l, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+
+	t.Cleanup(func() {
+		l.Close()
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Read(make([]byte, bufSize))
+
+			for i := 0; i < len(response); i += chunkSize {
+				conn.Write(response[i:min(i+chunkSize, len(response))])
+				time.Sleep(time.Microsecond)
+			}
+
+			conn.Close()
+		}
+	}()
+
+	return socket
+}
+
+func TestRawRequestDribble(t *testing.T) {
+	var clients []Client
+	for i := 0; i < 500; i++ {
+		clients = append(clients, Client{
+			Address: WindowAddress(fmt.Sprintf("0x%x", i)),
+			Class:   "kitty",
+			Title:   strings.Repeat("title", 10),
+		})
+	}
+
+	want, err := json.Marshal(clients)
+	assert.NoError(t, err)
+	assert.Greater(t, len(want), bufSize)
+
+	client := NewClient(dribbleServer(t, want, 100))
+
+	got, err := client.Clients()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, clients)
+
+	// Exactly the size of the buffer
+	want = []byte(strings.Repeat("a", bufSize))
+	client = NewClient(dribbleServer(t, want, bufSize/2))
+
+	response, err := client.RawRequest([]byte("splash"))
+	assert.NoError(t, err)
+	assert.DeepEqual(t, response, RawResponse(want))
+}
+
+func TestRawRequestTooBig(t *testing.T) {
+	response := []byte(strings.Repeat("a", 1024))
+
+	client := NewClient(dribbleServer(t, response, 100), WithMaxResponseSize(1024))
+	_, err := client.RawRequest([]byte("splash"))
+	assert.NoError(t, err)
+
+	client = NewClient(dribbleServer(t, response, 100), WithMaxResponseSize(1023))
+	_, err = client.RawRequest([]byte("splash"))
+	assert.True(t, errors.Is(err, ErrResponseTooBig))
+}
+
+func TestRawRequestTimeout(t *testing.T) {
+	socket, _ := hungServer(t)
+	client := NewClient(socket, WithTimeout(50*time.Millisecond))
+
+	_, err := client.RawRequest([]byte("splash"))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
 func TestActiveWindow(t *testing.T) {
 	testCommand(t, c.ActiveWindow, Window{})
 }
@@ -438,3 +689,95 @@ func TestVersion(t *testing.T) {
 		assert.Equal(t, v.Tag, "v"+HYPRLAND_VERSION)
 	}
 }
+
+// Starts a server that replies with the content of 'testdata/<name>', to test
+// the response parsing without a running Hyprland instance.
+func fixtureServer(t *testing.T, name string) *RequestClient {
+	t.Helper()
+
+	response, err := os.ReadFile(filepath.Join("testdata", name))
+	assert.NoError(t, err)
+
+	return NewClient(dribbleServer(t, response, len(response)))
+}
+
+func TestDescriptionsFixture(t *testing.T) {
+	got, err := fixtureServer(t, "descriptions.json").Descriptions()
+	assert.NoError(t, err)
+	assert.Equal(t, len(got), 7)
+
+	assert.Equal(t, got[0].Value, "general:border_size")
+	assert.Equal(t, got[0].Type, OptionInt)
+	assert.Equal(t, string(got[0].Data.Default), "1")
+	assert.Equal(t, string(got[0].Data.Current), "2")
+	assert.Equal(t, got[0].Data.Max, 20)
+
+	assert.Equal(t, got[1].Type, OptionBool)
+	assert.Equal(t, string(got[1].Data.Default), "false")
+
+	assert.Equal(t, got[2].Type, OptionGradient)
+	assert.Equal(t, string(got[2].Data.Default), `"0xffffffff"`)
+
+	assert.Equal(t, got[4].Type, OptionFloat)
+	assert.Equal(t, got[4].Flags&OptionFlagPercentage, OptionFlagPercentage)
+
+	assert.Equal(t, got[5].Type, OptionVector)
+	assert.Equal(t, got[5].Data.MinX, -250)
+
+	assert.Equal(t, got[6].Type, OptionChoice)
+	assert.Equal(t, got[6].Data.Choices, "master,slave,inherit")
+}
+
+func TestGlobalShortcutsFixture(t *testing.T) {
+	got, err := fixtureServer(t, "globalshortcuts.json").GlobalShortcuts()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, []GlobalShortcut{
+		{Name: "com.obsproject.Studio:toggle_recording", Description: "Toggle recording"},
+		{Name: "discord:mute", Description: "Mute microphone"},
+	})
+}
+
+func TestLayoutsFixture(t *testing.T) {
+	got, err := fixtureServer(t, "layouts.json").Layouts()
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, []string{"dwindle", "master"})
+}
+
+func TestRollingLogFixture(t *testing.T) {
+	got, err := fixtureServer(t, "rollinglog.txt").RollingLog()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(got, "[LOG] Creating the CHyprOpenGLImpl!\n"))
+}
+
+func TestSubmapFixture(t *testing.T) {
+	got, err := fixtureServer(t, "submap.txt").Submap()
+	assert.NoError(t, err)
+	assert.Equal(t, got, "resize")
+}
+
+func TestSystemInfoFixture(t *testing.T) {
+	got, err := fixtureServer(t, "systeminfo.txt").SystemInfo()
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(got, "System name: Linux\n"))
+}
+
+func TestWorkspaceRulesFixture(t *testing.T) {
+	got, err := fixtureServer(t, "workspacerules.json").WorkspaceRules()
+	assert.NoError(t, err)
+	assert.Equal(t, len(got), 2)
+
+	assert.Equal(t, got[0].WorkspaceString, "1")
+	assert.Equal(t, got[0].Monitor, "DP-1")
+	assert.True(t, got[0].Default)
+	assert.True(t, got[0].Persistent)
+	assert.True(t, got[0].BorderSize == nil)
+	assert.True(t, got[0].Border == nil)
+
+	assert.Equal(t, got[1].WorkspaceString, "special:scratchpad")
+	assert.DeepEqual(t, got[1].GapsIn, []int{5, 10, 5, 10})
+	assert.Equal(t, *got[1].BorderSize, 0)
+	assert.False(t, *got[1].Border)
+	assert.True(t, got[1].Decorate == nil)
+	assert.Equal(t, got[1].DefaultName, "scratchpad")
+	assert.Equal(t, got[1].OnCreatedEmptyCmd, "kitty")
+}
@@ -1,9 +1,14 @@
 package hyprland
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Indicates the version where the structs are up-to-date.
@@ -20,7 +25,14 @@ type Response string
 
 // RequestClient is the main struct from hyprland-go.
 type RequestClient struct {
-	conn *net.UnixAddr
+	conn            *net.UnixAddr
+	timeout         time.Duration
+	maxResponseSize int64
+
+	// Option schema cached by the first option query, see
+	// [RequestClient.GetOptionValues]
+	schemaMu sync.Mutex
+	schema   OptionSchema
 }
 
 // ErrValidation is used to return errors from response validation. In some
@@ -28,6 +40,45 @@ type RequestClient struct {
 // [errors.Is] to compare the errors returned with this type.
 var ErrValidation = errors.New("validation error")
 
+// BatchFailure is a param from a batch request that Hyprland did not accept.
+type BatchFailure struct {
+	// Index of the param in the request.
+	Index int
+	Param string
+	// Message returned by Hyprland instead of 'ok'.
+	Response Response
+}
+
+func (f BatchFailure) String() string {
+	return fmt.Sprintf("param %d ('%s'): %s", f.Index, f.Param, f.Response)
+}
+
+// BatchError is returned when Hyprland did not accept some of the params from
+// a batch request, e.g.: [RequestClient.Dispatch]. It lists every failed
+// param, so the params not listed were accepted. Use [errors.As] to access it,
+// it also matches [ErrValidation] with [errors.Is].
+type BatchError struct {
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		failures = append(failures, f.String())
+	}
+
+	return fmt.Sprintf(
+		"%s: %d non-ok response(s): %s",
+		ErrValidation,
+		len(e.Failures),
+		strings.Join(failures, ", "),
+	)
+}
+
+func (e *BatchError) Unwrap() error {
+	return ErrValidation
+}
+
 // Unmarshal structs for requests.
 // Try to keep struct fields in the same order as the output for `hyprctl -j`
 // for sanity.
@@ -68,7 +119,7 @@ const (
 )
 
 type Client struct {
-	Address          string          `json:"address"`
+	Address          WindowAddress   `json:"address"`
 	Mapped           bool            `json:"mapped"`
 	Hidden           bool            `json:"hidden"`
 	At               []int           `json:"at"`
@@ -76,7 +127,7 @@ type Client struct {
 	Workspace        WorkspaceType   `json:"workspace"`
 	Floating         bool            `json:"floating"`
 	Pseudo           bool            `json:"pseudo"`
-	Monitor          int             `json:"monitor"`
+	Monitor          MonitorID       `json:"monitor"`
 	Class            string          `json:"class"`
 	Title            string          `json:"title"`
 	InitialClass     string          `json:"initialClass"`
@@ -86,9 +137,9 @@ type Client struct {
 	Pinned           bool            `json:"pinned"`
 	Fullscreen       FullscreenState `json:"fullscreen"`
 	FullscreenClient FullscreenState `json:"fullscreenClient"`
-	Grouped          []string        `json:"grouped"`
+	Grouped          []WindowAddress `json:"grouped"`
 	Tags             []string        `json:"tags"`
-	Swallowing       string          `json:"swallowing"`
+	Swallowing       WindowAddress   `json:"swallowing"`
 	FocusHistoryId   int             `json:"focusHistoryID"`
 }
 
@@ -104,6 +155,54 @@ type Decoration struct {
 	Priority       int    `json:"priority"`
 }
 
+// OptionType is the type of a configuration option, see [Description].
+type OptionType int
+
+const (
+	OptionBool OptionType = iota
+	OptionInt
+	OptionFloat
+	OptionStringShort
+	OptionStringLong
+	OptionColor
+	OptionChoice
+	OptionGradient
+	OptionVector
+)
+
+// OptionFlagPercentage is set in [Description.Flags] when an
+// [OptionFloat] option is a percentage.
+const OptionFlagPercentage = 1 << 0
+
+// Description is the schema of a configuration option, e.g.:
+// 'general:border_size'.
+type Description struct {
+	Value       string          `json:"value"`
+	Description string          `json:"description"`
+	Type        OptionType      `json:"type"`
+	Flags       int             `json:"flags"`
+	Data        DescriptionData `json:"data"`
+}
+
+// DescriptionData has the default value and constraints of an option.
+// The fields that are set depend on [Description.Type].
+type DescriptionData struct {
+	// Default value, as a JSON boolean, number or string depending on
+	// the option type.
+	Default    json.RawMessage `json:"value"`
+	Current    json.RawMessage `json:"current"`
+	Min        float64         `json:"min"`
+	Max        float64         `json:"max"`
+	FirstIndex int             `json:"firstIndex"`
+	Choices    string          `json:"choices"` // comma separated
+	X          float64         `json:"x"`
+	Y          float64         `json:"y"`
+	MinX       float64         `json:"min_x"`
+	MinY       float64         `json:"min_y"`
+	MaxX       float64         `json:"max_x"`
+	MaxY       float64         `json:"max_y"`
+}
+
 type Devices struct {
 	Mice []struct {
 		Address      string  `json:"address"`
@@ -129,6 +228,11 @@ type Devices struct {
 	} `json:"switches"`
 }
 
+type GlobalShortcut struct {
+	Name        string `json:"name"` // in the format 'appid:id'
+	Description string `json:"description"`
+}
+
 type Output string
 
 type Layers map[Output]Layer
@@ -147,7 +251,7 @@ type LayerField struct {
 }
 
 type Monitor struct {
-	Id                     int           `json:"id"`
+	Id                     MonitorID     `json:"id"`
 	Name                   string        `json:"name"`
 	Description            string        `json:"description"`
 	Make                   string        `json:"make"`
@@ -186,13 +290,17 @@ type Monitor struct {
 }
 
 type Option struct {
-	Option string  `json:"option"`
-	Custom string  `json:"custom"`
-	Int    int     `json:"int"`
-	Float  float64 `json:"float"`
-	Set    bool    `json:"set"`
+	Option string    `json:"option"`
+	Custom string    `json:"custom"`
+	Int    int       `json:"int"`
+	Float  float64   `json:"float"`
+	Str    string    `json:"str"`
+	Vec2   []float64 `json:"vec2"`
+	Set    bool      `json:"set"`
 }
 
+// String returns the option value guessing its type from the fields that are
+// set, use [OptionSchema.Decode] to get a typed value instead.
 func (o Option) String() string {
 	if !o.Set {
 		return ""
@@ -200,6 +308,12 @@ func (o Option) String() string {
 	if o.Custom != "" {
 		return o.Custom
 	}
+	if o.Str != "" {
+		return o.Str
+	}
+	if len(o.Vec2) == 2 {
+		return Vec2{o.Vec2[0], o.Vec2[1]}.String()
+	}
 	if o.Float != 0 {
 		return strconv.FormatFloat(o.Float, 'f', -1, 64)
 	}
@@ -223,15 +337,34 @@ type Window struct {
 
 type Workspace struct {
 	WorkspaceType
-	Monitor         string `json:"monitor"`
-	MonitorID       int    `json:"monitorID"`
-	Windows         int    `json:"windows"`
-	HasFullScreen   bool   `json:"hasfullscreen"`
-	LastWindow      string `json:"lastwindow"`
-	LastWindowTitle string `json:"lastwindowtitle"`
+	Monitor         string        `json:"monitor"`
+	MonitorID       MonitorID     `json:"monitorID"`
+	Windows         int           `json:"windows"`
+	HasFullScreen   bool          `json:"hasfullscreen"`
+	LastWindow      WindowAddress `json:"lastwindow"`
+	LastWindowTitle string        `json:"lastwindowtitle"`
+}
+
+// WorkspaceRule is a 'workspace' rule from the configuration. Fields are only
+// set if the rule sets them, so nil means that the rule does not change the
+// default.
+type WorkspaceRule struct {
+	WorkspaceString   string `json:"workspaceString"`
+	Monitor           string `json:"monitor"`
+	Default           bool   `json:"default"`
+	Persistent        bool   `json:"persistent"`
+	GapsIn            []int  `json:"gapsIn"`  // top, right, bottom, left
+	GapsOut           []int  `json:"gapsOut"` // top, right, bottom, left
+	BorderSize        *int   `json:"borderSize"`
+	Border            *bool  `json:"border"`
+	Rounding          *bool  `json:"rounding"`
+	Decorate          *bool  `json:"decorate"`
+	Shadow            *bool  `json:"shadow"`
+	DefaultName       string `json:"defaultName"`
+	OnCreatedEmptyCmd string `json:"onCreatedEmptyCmd"`
 }
 
 type WorkspaceType struct {
-	Id   int    `json:"id"`
-	Name string `json:"name"`
+	Id   WorkspaceID `json:"id"`
+	Name string      `json:"name"`
 }
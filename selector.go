@@ -0,0 +1,343 @@
+package hyprland
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Returned when a selector is invalid, e.g.: 'address:foo'. Use
+// [errors.Is] to compare the errors returned with this type.
+var ErrInvalidSelector = errors.New("invalid selector")
+
+// WindowSelector selects a window in dispatchers, e.g.: 'address:0x1234',
+// 'class:^(kitty)$' or 'title:nvim'.
+// In dispatchers where the window is optional, an empty WindowSelector means
+// the active window.
+type WindowSelector string
+
+// WorkspaceSelector selects a workspace in dispatchers, e.g.: '1',
+// 'name:foo', 'special:scratch', 'r+1' or 'previous'.
+type WorkspaceSelector string
+
+// MonitorSelector selects a monitor in dispatchers, e.g.: 'DP-1', '+1' or
+// 'l'.
+// In dispatchers where the monitor is optional, an empty MonitorSelector means
+// all monitors (or the current one, depending on the dispatcher).
+type MonitorSelector string
+
+const (
+	// Selects the active window.
+	ActiveWindowSelector WindowSelector = "activewindow"
+	// Selects the first floating window.
+	FloatingWindowSelector WindowSelector = "floating"
+	// Selects the first tiled window.
+	TiledWindowSelector WindowSelector = "tiled"
+
+	// Selects the previous workspace.
+	PreviousWorkspace WorkspaceSelector = "previous"
+	// Selects the previous workspace on the current monitor.
+	PreviousPerMonitorWorkspace WorkspaceSelector = "previous_per_monitor"
+	// Selects the first available empty workspace.
+	EmptyWorkspace WorkspaceSelector = "empty"
+
+	// Selects the current monitor.
+	CurrentMonitor MonitorSelector = "current"
+)
+
+// Window selector kinds, i.e.: the part before ':' in a [WindowSelector].
+const (
+	windowKindAddress      = "address"
+	windowKindClass        = "class"
+	windowKindInitialClass = "initialclass"
+	windowKindTitle        = "title"
+	windowKindInitialTitle = "initialtitle"
+	windowKindTag          = "tag"
+	windowKindPid          = "pid"
+)
+
+var (
+	addressRegex          = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	relativeRegex         = regexp.MustCompile(`^[+-][0-9]+$`)
+	relativeWorkspaceExpr = regexp.MustCompile(`^[mre][-+~][0-9]+$`)
+	emptyWorkspaceRegex   = regexp.MustCompile(`^empty[mn]*$`)
+)
+
+// WindowByAddress selects a window by its address. The '0x' prefix is added
+// if missing, so both '0x1234' and '1234' works.
+func WindowByAddress(address WindowAddress) WindowSelector {
+	if !strings.HasPrefix(string(address), "0x") {
+		address = "0x" + address
+	}
+
+	return WindowSelector(windowKindAddress + ":" + string(address))
+}
+
+// WindowByClass selects a window by a class regex.
+func WindowByClass(regex string) WindowSelector {
+	return WindowSelector(windowKindClass + ":" + regex)
+}
+
+// WindowByInitialClass selects a window by an initial class regex.
+func WindowByInitialClass(regex string) WindowSelector {
+	return WindowSelector(windowKindInitialClass + ":" + regex)
+}
+
+// WindowByTitle selects a window by a title regex.
+func WindowByTitle(regex string) WindowSelector {
+	return WindowSelector(windowKindTitle + ":" + regex)
+}
+
+// WindowByInitialTitle selects a window by an initial title regex.
+func WindowByInitialTitle(regex string) WindowSelector {
+	return WindowSelector(windowKindInitialTitle + ":" + regex)
+}
+
+// WindowByTag selects a window by a tag.
+func WindowByTag(tag string) WindowSelector {
+	return WindowSelector(windowKindTag + ":" + tag)
+}
+
+// WindowByPid selects a window by its process ID.
+func WindowByPid(pid int) WindowSelector {
+	return WindowSelector(windowKindPid + ":" + strconv.Itoa(pid))
+}
+
+// ParseWindowSelector parses and validates a window selector, e.g.:
+// 'address:0x1234'. The returned selector will always be the same as the
+// input.
+func ParseWindowSelector(s string) (WindowSelector, error) {
+	w := WindowSelector(s)
+
+	return w, w.Validate()
+}
+
+// Validate the window selector, returning an error wrapping
+// [ErrInvalidSelector] if it is invalid.
+func (w WindowSelector) Validate() error {
+	switch w {
+	case "":
+		return selectorErr("window", string(w), "empty selector")
+	case ActiveWindowSelector, FloatingWindowSelector, TiledWindowSelector:
+		return nil
+	}
+
+	kind, value, found := strings.Cut(string(w), ":")
+	if !found || !isWindowKind(kind) {
+		// Without a known prefix, Hyprland handles it as a class regex
+		return validateRegex("window", string(w), string(w))
+	}
+
+	if value == "" {
+		return selectorErr("window", string(w), "empty value for "+kind)
+	}
+
+	switch kind {
+	case windowKindAddress:
+		if !addressRegex.MatchString(value) {
+			return selectorErr("window", string(w), "address should be an hex number starting with 0x")
+		}
+	case windowKindPid:
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return selectorErr("window", string(w), "pid should be a positive number")
+		}
+	case windowKindClass, windowKindInitialClass, windowKindTitle, windowKindInitialTitle:
+		return validateRegex("window", string(w), value)
+	}
+
+	return nil
+}
+
+func isWindowKind(kind string) bool {
+	switch kind {
+	case windowKindAddress, windowKindPid, windowKindClass, windowKindInitialClass,
+		windowKindTitle, windowKindInitialTitle, windowKindTag:
+		return true
+	}
+
+	return false
+}
+
+// WorkspaceByID selects a workspace by its ID.
+func WorkspaceByID(id WorkspaceID) WorkspaceSelector {
+	return WorkspaceSelector(id.String())
+}
+
+// WorkspaceByName selects a workspace by its name.
+func WorkspaceByName(name string) WorkspaceSelector {
+	return WorkspaceSelector("name:" + name)
+}
+
+// SpecialWorkspace selects a special workspace by its name. An empty name
+// means the default special workspace.
+func SpecialWorkspace(name string) WorkspaceSelector {
+	if name == "" {
+		return "special"
+	}
+
+	return WorkspaceSelector("special:" + name)
+}
+
+// RelativeWorkspace selects a workspace relative to the current one, e.g.:
+// RelativeWorkspace(-1) is the previous workspace ID.
+func RelativeWorkspace(n int) WorkspaceSelector {
+	return WorkspaceSelector(signedInt(n))
+}
+
+// MonitorWorkspace selects a workspace relative to the current one on the
+// same monitor ('m+1').
+func MonitorWorkspace(n int) WorkspaceSelector {
+	return WorkspaceSelector("m" + signedInt(n))
+}
+
+// MonitorWorkspaceIncludingEmpty selects a workspace relative to the current
+// one on the same monitor, including empty workspaces ('r+1').
+func MonitorWorkspaceIncludingEmpty(n int) WorkspaceSelector {
+	return WorkspaceSelector("r" + signedInt(n))
+}
+
+// OpenWorkspace selects an open workspace relative to the current one
+// ('e+1').
+func OpenWorkspace(n int) WorkspaceSelector {
+	return WorkspaceSelector("e" + signedInt(n))
+}
+
+// ParseWorkspaceSelector parses and validates a workspace selector, e.g.:
+// 'name:foo'. The returned selector will always be the same as the input.
+func ParseWorkspaceSelector(s string) (WorkspaceSelector, error) {
+	ws := WorkspaceSelector(s)
+
+	return ws, ws.Validate()
+}
+
+// Validate the workspace selector, returning an error wrapping
+// [ErrInvalidSelector] if it is invalid.
+func (ws WorkspaceSelector) Validate() error {
+	s := string(ws)
+
+	switch {
+	case s == "":
+		return selectorErr("workspace", s, "empty selector")
+	case ws == PreviousWorkspace, ws == PreviousPerMonitorWorkspace, ws == "special":
+		return nil
+	case emptyWorkspaceRegex.MatchString(s):
+		return nil
+	case relativeRegex.MatchString(s), relativeWorkspaceExpr.MatchString(s):
+		return nil
+	case strings.HasPrefix(s, "name:"):
+		if s == "name:" {
+			return selectorErr("workspace", s, "empty name")
+		}
+
+		return nil
+	case strings.HasPrefix(s, "special:"):
+		if s == "special:" {
+			return selectorErr("workspace", s, "empty special workspace name")
+		}
+
+		return nil
+	}
+
+	if _, err := strconv.Atoi(s); err != nil {
+		return selectorErr(
+			"workspace",
+			s,
+			"should be an ID, 'name:', 'special:', a relative (e.g.: 'r+1') or 'previous'",
+		)
+	}
+
+	return nil
+}
+
+// MonitorByName selects a monitor by its name, e.g.: 'DP-1'.
+func MonitorByName(name string) MonitorSelector {
+	return MonitorSelector(name)
+}
+
+// MonitorByDescription selects a monitor by its description.
+func MonitorByDescription(desc string) MonitorSelector {
+	return MonitorSelector("desc:" + desc)
+}
+
+// RelativeMonitor selects a monitor relative to the current one, e.g.:
+// RelativeMonitor(1) is the next monitor.
+func RelativeMonitor(n int) MonitorSelector {
+	return MonitorSelector(signedInt(n))
+}
+
+// MonitorInDirection selects the monitor in a direction from the current
+// one.
+func MonitorInDirection(d Direction) MonitorSelector {
+	return MonitorSelector(d)
+}
+
+// ParseMonitorSelector parses and validates a monitor selector, e.g.:
+// 'DP-1'. The returned selector will always be the same as the input.
+func ParseMonitorSelector(s string) (MonitorSelector, error) {
+	m := MonitorSelector(s)
+
+	return m, m.Validate()
+}
+
+// Validate the monitor selector, returning an error wrapping
+// [ErrInvalidSelector] if it is invalid.
+func (m MonitorSelector) Validate() error {
+	s := string(m)
+
+	switch {
+	case s == "":
+		return selectorErr("monitor", s, "empty selector")
+	case s == "desc:":
+		return selectorErr("monitor", s, "empty description")
+	case strings.HasPrefix(s, "desc:"):
+		return nil
+	case strings.HasPrefix(s, "+"), strings.HasPrefix(s, "-"):
+		if !relativeRegex.MatchString(s) {
+			return selectorErr("monitor", s, "relative monitor should be a number, e.g.: '+1'")
+		}
+
+		return nil
+	case strings.ContainsAny(s, " \t\n,"):
+		return selectorErr("monitor", s, "monitor name should not contain spaces or commas")
+	}
+
+	return nil
+}
+
+// Used to skip validation of selectors that are optional in dispatchers.
+type optional[T interface {
+	~string
+	Validate() error
+}] struct {
+	v T
+}
+
+func (o optional[T]) Validate() error {
+	if o.v == "" {
+		return nil
+	}
+
+	return o.v.Validate()
+}
+
+func selectorErr(kind, selector, reason string) error {
+	return fmt.Errorf("%w: %s '%s': %s", ErrInvalidSelector, kind, selector, reason)
+}
+
+func validateRegex(kind, selector, regex string) error {
+	if _, err := regexp.Compile(regex); err != nil {
+		return selectorErr(kind, selector, err.Error())
+	}
+
+	return nil
+}
+
+func signedInt(n int) string {
+	if n >= 0 {
+		return "+" + strconv.Itoa(n)
+	}
+
+	return strconv.Itoa(n)
+}
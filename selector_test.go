@@ -0,0 +1,145 @@
+package hyprland
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestWindowSelector(t *testing.T) {
+	tests := []struct {
+		selector WindowSelector
+		want     string
+	}{
+		{WindowByAddress("0x80864f60"), "address:0x80864f60"},
+		{WindowByAddress("80864f60"), "address:0x80864f60"},
+		{WindowByClass("^(kitty)$"), "class:^(kitty)$"},
+		{WindowByInitialClass("kitty"), "initialclass:kitty"},
+		{WindowByTitle("nvim"), "title:nvim"},
+		{WindowByInitialTitle("nvim"), "initialtitle:nvim"},
+		{WindowByTag("foo"), "tag:foo"},
+		{WindowByPid(1234), "pid:1234"},
+		{ActiveWindowSelector, "activewindow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, string(tt.selector), tt.want)
+			assert.NoError(t, tt.selector.Validate())
+
+			// make sure we can round-trip
+			w, err := ParseWindowSelector(tt.want)
+			assert.NoError(t, err)
+			assert.Equal(t, w, tt.selector)
+		})
+	}
+}
+
+func TestParseWindowSelectorClassRegex(t *testing.T) {
+	// Without a known prefix, selectors are class regexes
+	for _, tt := range []string{"kitty", "^(kitty)$", "steam_app:foo", "org:bar", "adress:0x80864f60"} {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseWindowSelector(tt)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseWindowSelectorError(t *testing.T) {
+	tests := []string{
+		"",
+		"address:",
+		"address:80864f60",
+		"address:0xfoo",
+		"foo:(bar",
+		"pid:-1",
+		"pid:foo",
+		"class:(kitty",
+		"(kitty",
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseWindowSelector(tt)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidSelector))
+		})
+	}
+}
+
+func TestWorkspaceSelector(t *testing.T) {
+	tests := []struct {
+		selector WorkspaceSelector
+		want     string
+	}{
+		{WorkspaceByID(1), "1"},
+		{WorkspaceByName("foo"), "name:foo"},
+		{SpecialWorkspace(""), "special"},
+		{SpecialWorkspace("scratch"), "special:scratch"},
+		{RelativeWorkspace(1), "+1"},
+		{RelativeWorkspace(-1), "-1"},
+		{MonitorWorkspace(-2), "m-2"},
+		{MonitorWorkspaceIncludingEmpty(1), "r+1"},
+		{OpenWorkspace(1), "e+1"},
+		{PreviousWorkspace, "previous"},
+		{PreviousPerMonitorWorkspace, "previous_per_monitor"},
+		{EmptyWorkspace, "empty"},
+		{"emptynm", "emptynm"},
+		{"r~3", "r~3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, string(tt.selector), tt.want)
+			assert.NoError(t, tt.selector.Validate())
+
+			ws, err := ParseWorkspaceSelector(tt.want)
+			assert.NoError(t, err)
+			assert.Equal(t, ws, tt.selector)
+		})
+	}
+}
+
+func TestParseWorkspaceSelectorError(t *testing.T) {
+	tests := []string{"", "name:", "special:", "foo", "r+", "x+1", "+1a", "emptyx"}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseWorkspaceSelector(tt)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidSelector))
+		})
+	}
+}
+
+func TestMonitorSelector(t *testing.T) {
+	tests := []struct {
+		selector MonitorSelector
+		want     string
+	}{
+		{MonitorByName("DP-1"), "DP-1"},
+		{MonitorByDescription("Dell Inc. DELL U2720Q"), "desc:Dell Inc. DELL U2720Q"},
+		{RelativeMonitor(1), "+1"},
+		{RelativeMonitor(-1), "-1"},
+		{MonitorInDirection(DirectionLeft), "l"},
+		{CurrentMonitor, "current"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, string(tt.selector), tt.want)
+			assert.NoError(t, tt.selector.Validate())
+
+			m, err := ParseMonitorSelector(tt.want)
+			assert.NoError(t, err)
+			assert.Equal(t, m, tt.selector)
+		})
+	}
+}
+
+func TestParseMonitorSelectorError(t *testing.T) {
+	tests := []string{"", "desc:", "+", "+a", "DP 1", "DP-1,DP-2"}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseMonitorSelector(tt)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidSelector))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package state
+
+import (
+	"context"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/event"
+)
+
+// Enriched is an event together with the client, workspace and monitor that
+// it refers to, e.g.: for [event.EventUrgent] the urgent client, its
+// workspace and its monitor. Fields are nil if the event does not refer to
+// them or if they could not be found.
+type Enriched struct {
+	event.Event
+
+	Client    *hyprland.Client
+	Workspace *hyprland.Workspace
+	Monitor   *hyprland.Monitor
+}
+
+// Lookup enriches a single event doing a batched request to Hyprland, for
+// usage without a [Cache]. Since the state is queried after the event, objects
+// removed by the event (e.g.: the client in closewindow) cannot be resolved.
+func Lookup(ctx context.Context, client *hyprland.RequestClient, e event.Event) (Enriched, error) {
+	s, err := query(ctx, client)
+	if err != nil {
+		return Enriched{Event: e}, err
+	}
+
+	return s.Enrich(e), nil
+}
+
+// LookupFunc returns a function that enriches events with [Lookup] before
+// calling f, to be used with e.g.: [event.Router.On] or
+// [event.NewAsyncDispatcher]. If the request fails, f is called with the
+// event not enriched.
+func LookupFunc(client *hyprland.RequestClient, f func(e Enriched)) func(e event.Event) {
+	return func(e event.Event) {
+		enriched, _ := Lookup(context.Background(), client, e)
+		f(enriched)
+	}
+}
+
+// Enrich returns the event with the objects that it refers to in the
+// current state.
+func (c *Cache) Enrich(e event.Event) Enriched {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.snapshot.Enrich(e)
+}
+
+// Enrich returns the event with the objects that it refers to in s.
+//
+//nolint:cyclop
+func (s *Snapshot) Enrich(e event.Event) Enriched {
+	var (
+		ws = -1
+		m  = -1
+	)
+
+	enriched := Enriched{Event: e}
+
+	if addr := e.Address(); addr != "" {
+		if i := s.client(addr); i >= 0 {
+			cl := cloneClients(s.Clients[i : i+1])[0]
+			enriched.Client = &cl
+			ws = s.workspace(cl.Workspace.Id)
+		}
+	}
+
+	switch v := e.Data.(type) {
+	case event.WorkspaceName:
+		ws = s.workspaceByName(string(v))
+	case event.MoveWorkspace:
+		ws, m = s.workspaceByName(string(v.WorkspaceName)), s.monitor(string(v.MonitorName))
+	case event.FocusedMonitor:
+		ws, m = s.workspaceByName(string(v.WorkspaceName)), s.monitor(string(v.MonitorName))
+	case event.MonitorName:
+		m = s.monitor(string(v))
+	case event.WorkspaceV2:
+		ws = s.workspace(v.ID)
+	case event.CreateWorkspaceV2:
+		ws = s.workspace(v.ID)
+	case event.DestroyWorkspaceV2:
+		ws = s.workspace(v.ID)
+	case event.RenameWorkspace:
+		ws = s.workspace(v.ID)
+	case event.ActiveSpecialV2:
+		ws = s.workspace(v.ID)
+	case event.MoveWorkspaceV2:
+		ws, m = s.workspace(v.ID), s.monitor(string(v.MonitorName))
+	case event.FocusedMonitorV2:
+		ws, m = s.workspace(v.WorkspaceID), s.monitor(string(v.MonitorName))
+	case event.MonitorAddedV2:
+		m = s.monitor(string(v.Name))
+	case event.MonitorRemovedV2:
+		m = s.monitor(string(v.Name))
+	}
+
+	if ws >= 0 {
+		w := s.Workspaces[ws]
+		enriched.Workspace = &w
+
+		if m < 0 {
+			m = s.monitor(w.Monitor)
+		}
+	}
+
+	if m >= 0 {
+		mon := cloneMonitors(s.Monitors[m : m+1])[0]
+		enriched.Monitor = &mon
+	}
+
+	return enriched
+}
+
+// Returns e with the missing fields from other.
+func (e Enriched) or(other Enriched) Enriched {
+	if e.Client == nil {
+		e.Client = other.Client
+	}
+
+	if e.Workspace == nil {
+		e.Workspace = other.Workspace
+	}
+
+	if e.Monitor == nil {
+		e.Monitor = other.Monitor
+	}
+
+	return e
+}
+
+func (s *Snapshot) workspaceByName(name string) int {
+	for i, ws := range s.Workspaces {
+		if ws.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,143 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/event"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+var testSnapshot = Snapshot{
+	Clients: []hyprland.Client{
+		{Address: "0x1000", Workspace: hyprland.WorkspaceType{Id: 2, Name: "web"}, Monitor: 1},
+	},
+	Workspaces: []hyprland.Workspace{
+		{WorkspaceType: hyprland.WorkspaceType{Id: 1, Name: "1"}, Monitor: "DP-1", MonitorID: 0},
+		{WorkspaceType: hyprland.WorkspaceType{Id: 2, Name: "web"}, Monitor: "HDMI-A-1", MonitorID: 1},
+	},
+	Monitors: []hyprland.Monitor{{Id: 0, Name: "DP-1"}, {Id: 1, Name: "HDMI-A-1"}},
+}
+
+func TestSnapshotEnrich(t *testing.T) {
+	type want struct {
+		client, workspace, monitor string
+	}
+
+	tests := []struct {
+		event event.Event
+		want  want
+	}{
+		{event.Event{Type: event.EventUrgent, Data: event.Urgent{Address: "0x1000"}}, want{"0x1000", "web", "HDMI-A-1"}},
+		{event.Event{Type: event.EventActiveWindowV2, Data: event.ActiveWindowV2{Address: "0x1000"}}, want{"0x1000", "web", "HDMI-A-1"}},
+		{event.Event{Type: event.EventPin, Data: event.Pin{Address: "0x2000"}}, want{}},
+		{event.Event{Type: event.EventWorkspace, Data: event.WorkspaceName("1")}, want{"", "1", "DP-1"}},
+		{event.Event{Type: event.EventWorkspaceV2, Data: event.WorkspaceV2{ID: 2, Name: "web"}}, want{"", "web", "HDMI-A-1"}},
+		{
+			event.Event{Type: event.EventMoveWorkspaceV2, Data: event.MoveWorkspaceV2{ID: 1, Name: "1", MonitorName: "HDMI-A-1"}},
+			want{"", "1", "HDMI-A-1"},
+		},
+		{
+			event.Event{Type: event.EventFocusedMonitorV2, Data: event.FocusedMonitorV2{MonitorName: "DP-1", WorkspaceID: 1}},
+			want{"", "1", "DP-1"},
+		},
+		{event.Event{Type: event.EventMonitorAdded, Data: event.MonitorName("DP-1")}, want{"", "", "DP-1"}},
+		{event.Event{Type: event.EventConfigReloaded}, want{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.event.Type), func(t *testing.T) {
+			e := testSnapshot.Enrich(tt.event)
+
+			var got want
+			if e.Client != nil {
+				got.client = e.Client.Address.String()
+			}
+
+			if e.Workspace != nil {
+				got.workspace = e.Workspace.Name
+			}
+
+			if e.Monitor != nil {
+				got.monitor = e.Monitor.Name
+			}
+
+			assert.Equal(t, got, tt.want)
+			assert.DeepEqual(t, e.Event, tt.event)
+		})
+	}
+}
+
+func TestCacheOnEvent(t *testing.T) {
+	s, c, _ := setup(t)
+
+	events := make(chan Enriched, 10)
+	off := c.OnEvent(func(e Enriched) { events <- e })
+
+	_, err := s.Client().DispatchAll(hyprland.Exec("kitty"), hyprland.CloseWindow(hyprland.WindowByClass("kitty")))
+	assert.NoError(t, err)
+	assert.NoError(t, s.Emit("urgent", "abc"))
+
+	var got []Enriched
+
+	for len(got) < 7 {
+		select {
+		case e := <-events:
+			got = append(got, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout while waiting for events, got: %d", len(got))
+		}
+	}
+
+	// openwindow, activewindow, activewindowv2, closewindow, activewindow,
+	// activewindowv2 (without an active window) and urgent
+	assert.Equal(t, got[0].Type, event.EventOpenWindow)
+	assert.Equal(t, got[0].Client.Class, "kitty")
+	assert.Equal(t, got[0].Workspace.Id, 1)
+	assert.Equal(t, got[0].Monitor.Name, "DP-1")
+	assert.Equal(t, got[2].Client.Address, "0x1000")
+
+	// Removed client is resolved from the state before the event
+	assert.Equal(t, got[3].Type, event.EventCloseWindow)
+	assert.Equal(t, got[3].Client.Address, "0x1000")
+
+	// Unknown address
+	assert.Equal(t, got[6].Type, event.EventUrgent)
+	assert.True(t, got[6].Client == nil)
+
+	off()
+
+	_, err = s.Client().DispatchAll(hyprland.Exec("foot"))
+	assert.NoError(t, err)
+
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event after unregistering: %v", e.Event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestLookup(t *testing.T) {
+	s := hyprlandtest.NewServer(t)
+	s.SetState(hyprlandtest.State(testSnapshot))
+
+	e, err := Lookup(context.Background(), s.Client(), event.Event{
+		Type: event.EventMoveWindowV2,
+		Data: event.MoveWindowV2{Address: "0x1000", WorkspaceID: 2, WorkspaceName: "web"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, e.Client.Address, "0x1000")
+	assert.Equal(t, e.Workspace.Name, "web")
+	assert.Equal(t, e.Monitor.Name, "HDMI-A-1")
+
+	// Single request for all queries
+	assert.Equal(t, len(s.Requests()), 1)
+
+	got := make(chan Enriched, 1)
+	f := LookupFunc(s.Client(), func(e Enriched) { got <- e })
+	f(event.Event{Type: event.EventBell, Data: event.Bell{Address: "0x1000"}})
+	assert.Equal(t, (<-got).Client.Address, "0x1000")
+}
@@ -0,0 +1,591 @@
+// Package state provides an in-memory model of the compositor state, that is
+// bootstrapped from [hyprland.RequestClient] queries and kept up-to-date
+// with the event stream.
+package state
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/event"
+)
+
+// Snapshot is a copy of the compositor state at a given time.
+type Snapshot struct {
+	Clients    []hyprland.Client
+	Workspaces []hyprland.Workspace
+	Monitors   []hyprland.Monitor
+}
+
+// Change is sent to subscribers each time the state changes, see
+// [Cache.Changes].
+type Change struct {
+	// Event that caused the change, empty if Resync is true.
+	Enriched
+	// True if the change was caused by a resync.
+	Resync bool
+}
+
+// Cache keeps an in-memory model of the compositor state, so the state can
+// be read without doing requests to Hyprland, e.g.:
+//
+//	ec := event.MustClient()
+//	cache, err := state.New(hyprland.MustClient())
+//	if err != nil {
+//		return err
+//	}
+//	go cache.Run(ctx, ec, time.Minute)
+//	clients := cache.Snapshot().Clients
+//
+// Not all details are available in events (e.g.: window groups, sizes and
+// positions), so those fields are only updated on resync. It is safe for
+// concurrent use.
+type Cache struct {
+	client *hyprland.RequestClient
+
+	mu       sync.RWMutex
+	snapshot Snapshot
+	subs     map[chan Change]struct{}
+	nextID   uint64
+	handlers []handler
+}
+
+type handler struct {
+	id uint64
+	f  func(e Enriched)
+}
+
+// New creates a new [Cache], bootstrapping it with the current state.
+// To avoid missing events, create the [event.EventClient] used in
+// [Cache.Run] before calling it.
+func New(client *hyprland.RequestClient) (*Cache, error) {
+	return NewContext(context.Background(), client)
+}
+
+// NewContext is like [New], but with a context.
+func NewContext(ctx context.Context, client *hyprland.RequestClient) (*Cache, error) {
+	c := &Cache{
+		client: client,
+		subs:   map[chan Change]struct{}{},
+	}
+	if err := c.ResyncContext(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Resync replaces the current state with the result of new queries, to
+// correct any drift from events.
+func (c *Cache) Resync() error {
+	return c.ResyncContext(context.Background())
+}
+
+// ResyncContext is like [Cache.Resync], but with a context.
+func (c *Cache) ResyncContext(ctx context.Context) error {
+	s, err := query(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("error while syncing state: %w", err)
+	}
+
+	c.mu.Lock()
+	c.snapshot = s
+	c.mu.Unlock()
+
+	c.notify(Change{Resync: true})
+
+	return nil
+}
+
+// Run receives events from ec and apply them until ctx is cancelled or an
+// error happens. If resync is bigger than zero, the state is also resynced
+// periodically. The state is always resynced after ec reconnects, see
+// [event.WithReconnect].
+func (c *Cache) Run(ctx context.Context, ec *event.EventClient, resync time.Duration) error {
+	// Stop receiving events if Run returns early, e.g.: on resync errors
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, errCh := ec.Events(ctx, 100)
+
+	var tick <-chan time.Time
+
+	if resync > 0 {
+		ticker := time.NewTicker(resync)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return <-errCh
+			}
+
+			if e.Type == event.EventReconnected {
+				if err := c.ResyncContext(ctx); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			c.Apply(e)
+		case <-tick:
+			if err := c.ResyncContext(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Snapshot returns a copy of the current state.
+func (c *Cache) Snapshot() Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return Snapshot{
+		Clients:    cloneClients(c.snapshot.Clients),
+		Workspaces: slices.Clone(c.snapshot.Workspaces),
+		Monitors:   cloneMonitors(c.snapshot.Monitors),
+	}
+}
+
+// Client returns the client with the given address, e.g.: '0x1234'.
+func (c *Cache) Client(address hyprland.WindowAddress) (cl hyprland.Client, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i := c.snapshot.client(address); i >= 0 {
+		return cloneClients(c.snapshot.Clients[i : i+1])[0], true
+	}
+
+	return cl, false
+}
+
+// ActiveClient returns the focused client.
+func (c *Cache) ActiveClient() (cl hyprland.Client, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i, client := range c.snapshot.Clients {
+		if client.FocusHistoryId == 0 {
+			return cloneClients(c.snapshot.Clients[i : i+1])[0], true
+		}
+	}
+
+	return cl, false
+}
+
+// Workspace returns the workspace with the given ID.
+func (c *Cache) Workspace(id hyprland.WorkspaceID) (w hyprland.Workspace, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i := c.snapshot.workspace(id); i >= 0 {
+		return c.snapshot.Workspaces[i], true
+	}
+
+	return w, false
+}
+
+// Monitor returns the monitor with the given name, e.g.: 'DP-1'.
+func (c *Cache) Monitor(name string) (m hyprland.Monitor, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i := c.snapshot.monitor(name); i >= 0 {
+		return cloneMonitors(c.snapshot.Monitors[i : i+1])[0], true
+	}
+
+	return m, false
+}
+
+// Changes returns a channel that receives a [Change] each time the state
+// changes, and a function to stop receiving them. Changes are dropped if the
+// channel buffer is full, so a resync should be considered in this case.
+func (c *Cache) Changes(buffer int) (<-chan Change, func()) {
+	ch := make(chan Change, buffer)
+
+	c.mu.Lock()
+	c.subs[ch] = struct{}{}
+	c.mu.Unlock()
+
+	var once sync.Once
+
+	return ch, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			delete(c.subs, ch)
+			c.mu.Unlock()
+
+			close(ch)
+		})
+	}
+}
+
+func (c *Cache) notify(change Change) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for ch := range c.subs {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+}
+
+// OnEvent registers f to be called with each event received by [Cache.Run],
+// enriched with the state after the event is applied, and returns a function
+// to unregister it. Objects removed by the event (e.g.: the client in
+// closewindow) are resolved using the state before the event.
+// Handlers are called in order from the same goroutine as [Cache.Run], so
+// slow handlers delay the state updates.
+func (c *Cache) OnEvent(f func(e Enriched)) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	id := c.nextID
+	c.handlers = append(c.handlers, handler{id, f})
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.handlers = slices.DeleteFunc(slices.Clone(c.handlers), func(h handler) bool { return h.id == id })
+	}
+}
+
+// Apply an event to the state, calling the handlers registered in
+// [Cache.OnEvent] and notifying subscribers if the state changed.
+// Generally there is no need to call it directly, use [Cache.Run] instead.
+func (c *Cache) Apply(e event.Event) {
+	c.mu.Lock()
+	before := c.snapshot.Enrich(e)
+	changed := c.snapshot.apply(e)
+	enriched := c.snapshot.Enrich(e).or(before)
+	// Unregistering replaces the slice, so it is safe to use it unlocked
+	handlers := c.handlers
+	c.mu.Unlock()
+
+	for _, h := range handlers {
+		h.f(enriched)
+	}
+
+	if changed {
+		c.notify(Change{Enriched: enriched})
+	}
+}
+
+// Apply an event, returning true if the state changed.
+//
+//nolint:cyclop,funlen
+func (s *Snapshot) apply(e event.Event) bool {
+	switch v := e.Data.(type) {
+	case event.OpenWindow:
+		ws := s.workspaceType(string(v.WorkspaceName))
+		s.Clients = append(s.Clients, hyprland.Client{
+			Address:        v.Address,
+			Mapped:         true,
+			Workspace:      ws,
+			Monitor:        s.workspaceMonitorID(ws.Id),
+			Class:          v.Class,
+			Title:          v.Title,
+			InitialClass:   v.Class,
+			InitialTitle:   v.Title,
+			FocusHistoryId: len(s.Clients),
+		})
+		s.updateWorkspaces()
+	case event.CloseWindow:
+		i := s.client(v.Address)
+		if i < 0 {
+			return false
+		}
+
+		closed := s.Clients[i]
+		s.Clients = slices.Delete(s.Clients, i, i+1)
+
+		for j := range s.Clients {
+			if s.Clients[j].FocusHistoryId > closed.FocusHistoryId {
+				s.Clients[j].FocusHistoryId--
+			}
+		}
+
+		s.updateWorkspaces()
+	case event.MoveWindowV2:
+		i := s.client(v.Address)
+		if i < 0 {
+			return false
+		}
+
+		s.Clients[i].Workspace = hyprland.WorkspaceType{Id: v.WorkspaceID, Name: string(v.WorkspaceName)}
+		s.Clients[i].Monitor = s.workspaceMonitorID(v.WorkspaceID)
+		s.updateWorkspaces()
+	case event.WindowTitleV2:
+		i := s.client(v.Address)
+		if i < 0 {
+			return false
+		}
+
+		s.Clients[i].Title = v.Title
+		s.updateWorkspaces()
+	case event.ActiveWindowV2:
+		i := s.client(v.Address)
+		if i < 0 {
+			return false
+		}
+
+		s.focus(i)
+		s.updateWorkspaces()
+	case event.ChangeFloatingMode:
+		i := s.client(v.Address)
+		if i < 0 {
+			return false
+		}
+
+		s.Clients[i].Floating = v.Floating
+	case event.Pin:
+		i := s.client(v.Address)
+		if i < 0 {
+			return false
+		}
+
+		s.Clients[i].Pinned = v.Pinned
+	case event.Fullscreen:
+		// Applies to the active window
+		i := s.focused()
+		if i < 0 {
+			return false
+		}
+
+		s.Clients[i].Fullscreen = hyprland.None
+		if v {
+			s.Clients[i].Fullscreen = hyprland.Fullscreen
+		}
+
+		s.updateWorkspaces()
+	case event.WorkspaceV2:
+		ws := hyprland.WorkspaceType{Id: v.ID, Name: string(v.Name)}
+		for i := range s.Monitors {
+			if s.Monitors[i].Focused {
+				s.Monitors[i].ActiveWorkspace = ws
+			}
+		}
+	case event.FocusedMonitorV2:
+		for i := range s.Monitors {
+			s.Monitors[i].Focused = s.Monitors[i].Name == string(v.MonitorName)
+			if s.Monitors[i].Focused {
+				s.Monitors[i].ActiveWorkspace = hyprland.WorkspaceType{Id: v.WorkspaceID, Name: v.WorkspaceID.String()}
+				if j := s.workspace(v.WorkspaceID); j >= 0 {
+					s.Monitors[i].ActiveWorkspace = s.Workspaces[j].WorkspaceType
+				}
+			}
+		}
+	case event.CreateWorkspaceV2:
+		if s.workspace(v.ID) >= 0 {
+			return false
+		}
+
+		// The monitor is not part of the event, so assume the
+		// focused one
+		ws := hyprland.Workspace{WorkspaceType: hyprland.WorkspaceType{Id: v.ID, Name: string(v.Name)}}
+		for _, m := range s.Monitors {
+			if m.Focused {
+				ws.Monitor, ws.MonitorID = m.Name, m.Id
+			}
+		}
+
+		s.Workspaces = append(s.Workspaces, ws)
+		s.updateWorkspaces()
+	case event.DestroyWorkspaceV2:
+		i := s.workspace(v.ID)
+		if i < 0 {
+			return false
+		}
+
+		s.Workspaces = slices.Delete(s.Workspaces, i, i+1)
+	case event.MoveWorkspaceV2:
+		i := s.workspace(v.ID)
+		if i < 0 {
+			return false
+		}
+
+		s.Workspaces[i].Monitor = string(v.MonitorName)
+		if m := s.monitor(string(v.MonitorName)); m >= 0 {
+			s.Workspaces[i].MonitorID = s.Monitors[m].Id
+		}
+	case event.RenameWorkspace:
+		rename := func(ws *hyprland.WorkspaceType) {
+			if ws.Id == v.ID {
+				ws.Name = string(v.NewName)
+			}
+		}
+
+		for i := range s.Workspaces {
+			rename(&s.Workspaces[i].WorkspaceType)
+		}
+
+		for i := range s.Clients {
+			rename(&s.Clients[i].Workspace)
+		}
+
+		for i := range s.Monitors {
+			rename(&s.Monitors[i].ActiveWorkspace)
+		}
+	case event.MonitorAddedV2:
+		if s.monitor(string(v.Name)) >= 0 {
+			return false
+		}
+
+		s.Monitors = append(s.Monitors, hyprland.Monitor{
+			Id:          v.ID,
+			Name:        string(v.Name),
+			Description: v.Description,
+		})
+	case event.MonitorRemovedV2:
+		i := s.monitor(string(v.Name))
+		if i < 0 {
+			return false
+		}
+
+		s.Monitors = slices.Delete(s.Monitors, i, i+1)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Focus the client at index i, updating the focus history.
+func (s *Snapshot) focus(i int) {
+	if i < 0 {
+		return
+	}
+
+	focused := s.Clients[i].FocusHistoryId
+
+	for j := range s.Clients {
+		if s.Clients[j].FocusHistoryId < focused {
+			s.Clients[j].FocusHistoryId++
+		}
+	}
+
+	s.Clients[i].FocusHistoryId = 0
+}
+
+// Update derived fields of workspaces, e.g.: the number of windows.
+func (s *Snapshot) updateWorkspaces() {
+	for i := range s.Workspaces {
+		ws := &s.Workspaces[i]
+		ws.Windows = 0
+		ws.HasFullScreen = false
+		ws.LastWindow = "0x0"
+		ws.LastWindowTitle = ""
+		lastFocus := -1
+
+		for _, cl := range s.Clients {
+			if cl.Workspace.Id != ws.Id {
+				continue
+			}
+
+			ws.Windows++
+			ws.HasFullScreen = ws.HasFullScreen || cl.Fullscreen != hyprland.None
+
+			if lastFocus < 0 || cl.FocusHistoryId < lastFocus {
+				lastFocus = cl.FocusHistoryId
+				ws.LastWindow = cl.Address
+				ws.LastWindowTitle = cl.Title
+			}
+		}
+	}
+}
+
+func (s *Snapshot) client(address hyprland.WindowAddress) int {
+	return slices.IndexFunc(s.Clients, func(cl hyprland.Client) bool { return cl.Address.Equal(address) })
+}
+
+func (s *Snapshot) focused() int {
+	return slices.IndexFunc(s.Clients, func(cl hyprland.Client) bool { return cl.FocusHistoryId == 0 })
+}
+
+func (s *Snapshot) workspace(id hyprland.WorkspaceID) int {
+	return slices.IndexFunc(s.Workspaces, func(ws hyprland.Workspace) bool { return ws.Id == id })
+}
+
+func (s *Snapshot) monitor(name string) int {
+	return slices.IndexFunc(s.Monitors, func(m hyprland.Monitor) bool { return m.Name == name })
+}
+
+// Returns the workspace by name, since some events only have the name.
+func (s *Snapshot) workspaceType(name string) hyprland.WorkspaceType {
+	if i := s.workspaceByName(name); i >= 0 {
+		return s.Workspaces[i].WorkspaceType
+	}
+
+	id, _ := hyprland.ParseWorkspaceID(name)
+
+	return hyprland.WorkspaceType{Id: id, Name: name}
+}
+
+func (s *Snapshot) workspaceMonitorID(id hyprland.WorkspaceID) hyprland.MonitorID {
+	if i := s.workspace(id); i >= 0 {
+		return s.Workspaces[i].MonitorID
+	}
+
+	return 0
+}
+
+// Returns the current state with a single request, so it is consistent.
+func query(ctx context.Context, client *hyprland.RequestClient) (s Snapshot, err error) {
+	err = client.QueryContext(
+		ctx,
+		hyprland.ClientsQuery(&s.Clients),
+		hyprland.WorkspacesQuery(&s.Workspaces),
+		hyprland.MonitorsQuery(&s.Monitors),
+	)
+
+	return s, err
+}
+
+func cloneClients(clients []hyprland.Client) []hyprland.Client {
+	if clients == nil {
+		return nil
+	}
+
+	cloned := make([]hyprland.Client, len(clients))
+	for i, cl := range clients {
+		cl.At = slices.Clone(cl.At)
+		cl.Size = slices.Clone(cl.Size)
+		cl.Grouped = slices.Clone(cl.Grouped)
+		cl.Tags = slices.Clone(cl.Tags)
+		cloned[i] = cl
+	}
+
+	return cloned
+}
+
+func cloneMonitors(monitors []hyprland.Monitor) []hyprland.Monitor {
+	if monitors == nil {
+		return nil
+	}
+
+	cloned := make([]hyprland.Monitor, len(monitors))
+	for i, m := range monitors {
+		m.Reserved = slices.Clone(m.Reserved)
+		m.SolitaryBlockedBy = slices.Clone(m.SolitaryBlockedBy)
+		m.TearingBlockedBy = slices.Clone(m.TearingBlockedBy)
+		m.DirectScanoutBlockedBy = slices.Clone(m.DirectScanoutBlockedBy)
+		m.AvailableModes = slices.Clone(m.AvailableModes)
+		cloned[i] = m
+	}
+
+	return cloned
+}
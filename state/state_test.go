@@ -0,0 +1,200 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/event"
+	"github.com/thiagokokada/hyprland-go/hyprlandtest"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+// Fields that can be derived from events, since the others are only
+// updated on resync.
+type clientFields struct {
+	Address        hyprland.WindowAddress
+	Workspace      hyprland.WorkspaceType
+	Class, Title   string
+	Floating       bool
+	FocusHistoryId int
+}
+
+type workspaceFields struct {
+	hyprland.WorkspaceType
+	Monitor    string
+	Windows    int
+	LastWindow hyprland.WindowAddress
+}
+
+func project(s Snapshot) (clients []clientFields, workspaces []workspaceFields, active []hyprland.WorkspaceType) {
+	for _, cl := range s.Clients {
+		clients = append(clients, clientFields{
+			cl.Address, cl.Workspace, cl.Class, cl.Title, cl.Floating, cl.FocusHistoryId,
+		})
+	}
+
+	for _, ws := range s.Workspaces {
+		workspaces = append(workspaces, workspaceFields{ws.WorkspaceType, ws.Monitor, ws.Windows, ws.LastWindow})
+	}
+
+	for _, m := range s.Monitors {
+		active = append(active, m.ActiveWorkspace)
+	}
+
+	return clients, workspaces, active
+}
+
+func setup(t *testing.T) (*hyprlandtest.Compositor, *Cache, <-chan Change) {
+	t.Helper()
+
+	s := hyprlandtest.NewCompositor(t, hyprlandtest.State{})
+
+	ec, err := event.NewClient(s.EventSocket)
+	assert.NoError(t, err)
+	t.Cleanup(func() { ec.Close() })
+
+	assert.NoError(t, s.WaitEventClients(1, time.Second))
+
+	c, err := New(s.Client())
+	assert.NoError(t, err)
+
+	changes, stop := c.Changes(100)
+	t.Cleanup(stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+
+	go func() { errCh <- c.Run(ctx, ec, 0) }()
+
+	t.Cleanup(func() {
+		cancel()
+		assert.True(t, errors.Is(<-errCh, context.Canceled))
+	})
+
+	return s, c, changes
+}
+
+// Wait until n changes are received.
+func waitChanges(t *testing.T, changes <-chan Change, n int) (got []Change) {
+	t.Helper()
+
+	for len(got) < n {
+		select {
+		case change := <-changes:
+			got = append(got, change)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout while waiting for changes: want: %d, got: %d", n, len(got))
+		}
+	}
+
+	return got
+}
+
+func assertSynced(t *testing.T, s *hyprlandtest.Compositor, c *Cache) {
+	t.Helper()
+
+	st := s.State()
+	wantClients, wantWorkspaces, wantActive := project(Snapshot{st.Clients, st.Workspaces, st.Monitors})
+	gotClients, gotWorkspaces, gotActive := project(c.Snapshot())
+
+	assert.DeepEqual(t, gotClients, wantClients)
+	assert.DeepEqual(t, gotWorkspaces, wantWorkspaces)
+	assert.DeepEqual(t, gotActive, wantActive)
+}
+
+func TestCache(t *testing.T) {
+	s, c, changes := setup(t)
+	rc := s.Client()
+
+	_, err := rc.DispatchAll(hyprland.Exec("kitty"), hyprland.Exec("foot"))
+	assert.NoError(t, err)
+
+	// openwindow and activewindowv2 for each window
+	waitChanges(t, changes, 4)
+	assertSynced(t, s, c)
+
+	_, err = rc.DispatchAll(
+		hyprland.FocusWorkspace(hyprland.WorkspaceByName("web")),
+		hyprland.MoveWindowToWorkspace(hyprland.WorkspaceByName("web"), hyprland.WindowByClass("kitty"), true),
+	)
+	assert.NoError(t, err)
+
+	// createworkspacev2, workspacev2 and movewindowv2
+	waitChanges(t, changes, 3)
+	assertSynced(t, s, c)
+
+	_, err = rc.DispatchAll(
+		hyprland.ToggleFloating(hyprland.WindowByClass("kitty")),
+		hyprland.CloseWindow(hyprland.WindowByClass("foot")),
+		hyprland.FocusWorkspace(hyprland.WorkspaceByID(1)),
+	)
+	assert.NoError(t, err)
+
+	// changefloatingmode, closewindow, workspacev2 and destroyworkspacev2
+	got := waitChanges(t, changes, 4)
+	assert.Equal(t, got[0].Event.Type, event.EventChangeFloatingMode)
+	assertSynced(t, s, c)
+
+	cl, ok := c.Client("0x1000")
+	assert.True(t, ok)
+	assert.True(t, cl.Floating)
+
+	_, ok = c.Client("0x1010")
+	assert.False(t, ok)
+
+	ws, ok := c.Workspace(1)
+	assert.True(t, ok)
+	assert.Equal(t, ws.Windows, 0)
+
+	m, ok := c.Monitor("DP-1")
+	assert.True(t, ok)
+	assert.Equal(t, m.ActiveWorkspace.Id, 1)
+}
+
+func TestCacheResync(t *testing.T) {
+	s, c, changes := setup(t)
+
+	// Changes without events, e.g.: drift
+	s.Update(func(st *hyprlandtest.State) {
+		st.Clients = append(st.Clients, hyprland.Client{Address: "0xabc", Title: "drift"})
+	})
+
+	_, ok := c.Client("0xabc")
+	assert.False(t, ok)
+
+	assert.NoError(t, c.Resync())
+	assert.True(t, waitChanges(t, changes, 1)[0].Resync)
+
+	cl, ok := c.Client("0xabc")
+	assert.True(t, ok)
+	assert.Equal(t, cl.Title, "drift")
+	assert.DeepEqual(t, c.Snapshot(), Snapshot(s.State()))
+}
+
+func TestCacheCopies(t *testing.T) {
+	s, c, changes := setup(t)
+
+	s.Update(func(st *hyprlandtest.State) {
+		st.Monitors[0].Reserved = []int{0, 30, 0, 0}
+		st.Monitors[0].TearingBlockedBy = []string{"vrr"}
+	})
+	assert.NoError(t, c.Resync())
+	waitChanges(t, changes, 1)
+
+	snapshot := c.Snapshot()
+	snapshot.Monitors[0].Reserved[1] = 0
+	snapshot.Monitors[0].TearingBlockedBy[0] = "foo"
+
+	m, ok := c.Monitor("DP-1")
+	assert.True(t, ok)
+	assert.DeepEqual(t, m.Reserved, []int{0, 30, 0, 0})
+	assert.DeepEqual(t, m.TearingBlockedBy, []string{"vrr"})
+
+	m.Reserved[1] = 0
+
+	m, _ = c.Monitor("DP-1")
+	assert.Equal(t, m.Reserved[1], 30)
+}
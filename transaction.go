@@ -0,0 +1,142 @@
+package hyprland
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Returned when a [Transaction] is used after it was committed or rolled
+// back.
+var ErrTransactionDone = errors.New("transaction already done")
+
+// Transaction is a set of option changes that can be reverted, e.g.: a
+// "presentation mode" that changes gaps, borders and animations:
+//
+//	tx, err := c.BeginTransaction(ctx,
+//		hyprland.OptionValue{Name: "general:gaps_out", Value: 0},
+//		hyprland.OptionValue{Name: "animations:enabled", Value: false},
+//	)
+//	if tx == nil {
+//		return err
+//	}
+//	defer tx.Rollback()
+//
+// A Transaction is safe for concurrent use.
+type Transaction struct {
+	client   *RequestClient
+	snapshot []OptionValue
+	stop     func() bool
+
+	mu   sync.Mutex
+	done bool
+	err  error
+}
+
+// BeginTransaction snapshots the current value of every option in options,
+// then applies all of them in a single batch request, see
+// [RequestClient.SetOptions].
+// The snapshot is restored by [Transaction.Rollback] or automatically when
+// ctx is done, unless [Transaction.Commit] is called before.
+//
+// If the snapshot fails, nothing is applied and the returned transaction is
+// nil. Otherwise, the transaction is returned even if some options failed to
+// apply or could not be formatted (the error is the same as returned by
+// [RequestClient.SetOptions]), so [Transaction.Rollback] can always be called.
+func (c *RequestClient) BeginTransaction(ctx context.Context, options ...OptionValue) (*Transaction, error) {
+	snapshot, err := c.snapshotOptions(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{client: c, snapshot: snapshot}
+	_, err = c.SetOptionsContext(ctx, options...)
+	tx.stop = context.AfterFunc(ctx, func() { tx.finish(true) })
+
+	return tx, err
+}
+
+// Snapshot returns the values of the options before the transaction, that
+// are restored by [Transaction.Rollback].
+func (tx *Transaction) Snapshot() []OptionValue {
+	return append([]OptionValue(nil), tx.snapshot...)
+}
+
+// Commit keeps the applied values, so they will not be restored when the
+// context is done. Returns [ErrTransactionDone] if the transaction was
+// already committed or rolled back.
+func (tx *Transaction) Commit() error {
+	tx.stop()
+
+	if !tx.finish(false) {
+		return ErrTransactionDone
+	}
+
+	return nil
+}
+
+// Rollback restores the snapshot in a single batch request, including options
+// that failed to apply. It can be called multiple times (e.g.: with defer),
+// returning the error of the first call, and does nothing after
+// [Transaction.Commit].
+func (tx *Transaction) Rollback() error {
+	tx.stop()
+	tx.finish(true)
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	return tx.err
+}
+
+// Marks the transaction as done, restoring the snapshot if rollback is true.
+// Returns false if the transaction was already done.
+func (tx *Transaction) finish(rollback bool) bool {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.done {
+		return false
+	}
+
+	tx.done = true
+
+	if rollback {
+		// The transaction context may be already done at this point
+		_, tx.err = tx.client.SetOptions(tx.snapshot...)
+	}
+
+	return true
+}
+
+// Returns the current value of each option, decoded so it can be restored by
+// [RequestClient.SetOptions], e.g.: choices are restored by name.
+func (c *RequestClient) snapshotOptions(ctx context.Context, options []OptionValue) ([]OptionValue, error) {
+	seen := make(map[string]bool, len(options))
+	names := make([]string, 0, len(options))
+
+	for _, o := range options {
+		if !seen[o.Name] {
+			seen[o.Name] = true
+			names = append(names, o.Name)
+		}
+	}
+
+	schema, current, err := c.queryOptions(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make([]OptionValue, 0, len(current))
+
+	for _, o := range current {
+		v, err := schema.Decode(o)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot = append(snapshot, OptionValue{o.Option, v})
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,156 @@
+package hyprland_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+// Returns the values of the options changed by the transaction tests.
+func transactionValues(t *testing.T, c *hyprland.RequestClient) map[string]any {
+	t.Helper()
+
+	v, err := c.GetOptionValues(
+		"general:border_size",
+		"general:col.active_border",
+		"decoration:active_opacity",
+		"master:new_status",
+	)
+	assert.NoError(t, err)
+
+	return v
+}
+
+func TestTransaction(t *testing.T) {
+	s := newOptionCompositor(t)
+	c := s.Client()
+	before := transactionValues(t, c)
+
+	tx, err := c.BeginTransaction(
+		context.Background(),
+		hyprland.OptionValue{Name: "general:border_size", Value: 0},
+		hyprland.OptionValue{Name: "general:col.active_border", Value: hyprland.RGB(0xff, 0, 0)},
+		hyprland.OptionValue{Name: "decoration:active_opacity", Value: 0.5},
+		hyprland.OptionValue{Name: "master:new_status", Value: 0},
+	)
+	assert.NoError(t, err)
+
+	after := transactionValues(t, c)
+	assert.Equal(t, after["general:border_size"], any(0))
+	assert.Equal(t, after["decoration:active_opacity"], any(0.5))
+	assert.Equal(t, after["master:new_status"], any("master"))
+	assert.DeepEqual(t, after["general:col.active_border"], any(hyprland.Gradient{
+		Colors: []hyprland.Color{hyprland.RGB(0xff, 0, 0)},
+	}))
+
+	assert.NoError(t, tx.Rollback())
+	assert.DeepEqual(t, transactionValues(t, c), before)
+
+	// Rollback only once
+	s.Reset()
+	assert.NoError(t, tx.Rollback())
+	assert.True(t, errors.Is(tx.Commit(), hyprland.ErrTransactionDone))
+	assert.Equal(t, len(s.Requests()), 0)
+}
+
+func TestTransactionCommit(t *testing.T) {
+	c := newOptionCompositor(t).Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx, err := c.BeginTransaction(ctx, hyprland.OptionValue{Name: "general:border_size", Value: 5})
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	cancel()
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, transactionValues(t, c)["general:border_size"], any(5))
+}
+
+func TestTransactionContext(t *testing.T) {
+	c := newOptionCompositor(t).Client()
+	before := transactionValues(t, c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := c.BeginTransaction(ctx, hyprland.OptionValue{Name: "general:border_size", Value: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, transactionValues(t, c)["general:border_size"], any(5))
+
+	cancel()
+
+	for i := 0; transactionValues(t, c)["general:border_size"] != before["general:border_size"]; i++ {
+		if i > 100 {
+			t.Fatal("timeout while waiting for rollback")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTransactionPartialFailure(t *testing.T) {
+	s := newOptionCompositor(t)
+	c := s.Client()
+	before := transactionValues(t, c)
+
+	tx, err := c.BeginTransaction(
+		context.Background(),
+		hyprland.OptionValue{Name: "general:border_size", Value: 5},
+		hyprland.OptionValue{Name: "decoration:active_opacity", Value: -1.0},
+	)
+	assert.True(t, errors.Is(err, hyprland.ErrValidation))
+	assert.True(t, tx != nil)
+	assert.Equal(t, transactionValues(t, c)["general:border_size"], any(5))
+
+	assert.NoError(t, tx.Rollback())
+	assert.DeepEqual(t, transactionValues(t, c), before)
+
+	// Snapshot fails: nothing is applied
+	s.Reset()
+
+	tx, err = c.BeginTransaction(context.Background(), hyprland.OptionValue{Name: "foo:bar", Value: 1})
+	assert.Error(t, err)
+	assert.True(t, tx == nil)
+	assert.Equal(t, len(s.Commands()), 1) // getoption, the schema is cached
+
+	// Invalid values: nothing is applied, but it can still be rolled back
+	tx, err = c.BeginTransaction(
+		context.Background(),
+		hyprland.OptionValue{Name: "general:border_size", Value: 5},
+		hyprland.OptionValue{Name: "decoration:active_opacity", Value: []int{1}},
+	)
+	assert.True(t, errors.Is(err, hyprland.ErrInvalidValue))
+	assert.True(t, tx != nil)
+	assert.DeepEqual(t, transactionValues(t, c), before)
+	assert.NoError(t, tx.Rollback())
+	assert.DeepEqual(t, transactionValues(t, c), before)
+}
+
+func TestTransactionRestore(t *testing.T) {
+	s := newOptionCompositor(t)
+	c := s.Client()
+
+	_, err := c.SetOption("general:layout", "")
+	assert.NoError(t, err)
+
+	tx, err := c.BeginTransaction(
+		context.Background(),
+		hyprland.OptionValue{Name: "general:layout", Value: "dwindle"},
+		hyprland.OptionValue{Name: "master:new_status", Value: "inherit"},
+	)
+	assert.NoError(t, err)
+
+	// Choices are restored by name, and empty strings are not sent as-is
+	s.Reset()
+	assert.NoError(t, tx.Rollback())
+	assert.DeepEqual(t, rawCommands(s), []string{"keyword general:layout [[EMPTY]]", "keyword master:new_status slave"})
+
+	got, err := c.GetOptionValues("general:layout", "master:new_status")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, got, map[string]any{"general:layout": "", "master:new_status": "slave"})
+}
@@ -0,0 +1,126 @@
+package hyprland
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Returned when a value can not be parsed or formatted, e.g.: an invalid
+// color. Use [errors.Is] to compare the errors returned with this type.
+var ErrInvalidValue = errors.New("invalid value")
+
+// Color is a RGBA color, formatted as 'rgba(rrggbbaa)' in requests.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// RGBA returns a color from its components.
+func RGBA(r, g, b, a uint8) Color {
+	return Color{R: r, G: g, B: b, A: a}
+}
+
+// RGB returns an opaque color from its components.
+func RGB(r, g, b uint8) Color {
+	return Color{R: r, G: g, B: b, A: 0xff}
+}
+
+// ParseColor parses a color in any of the formats used by Hyprland, e.g.:
+// 'rgba(33ccffee)', 'rgb(33ccff)', '0xee33ccff' (ARGB) or 'ee33ccff' (ARGB, as
+// returned by getprop).
+func ParseColor(s string) (Color, error) {
+	var (
+		hex   = s
+		alpha = true
+		argb  = false
+	)
+
+	switch {
+	case strings.HasPrefix(s, "rgba(") && strings.HasSuffix(s, ")"):
+		hex = s[len("rgba(") : len(s)-1]
+	case strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")"):
+		hex, alpha = s[len("rgb("):len(s)-1], false
+	default:
+		hex, argb = strings.TrimPrefix(s, "0x"), true
+	}
+
+	if (alpha && len(hex) != 8) || (!alpha && len(hex) != 6) {
+		return Color{}, fmt.Errorf("%w: color %q", ErrInvalidValue, s)
+	}
+
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("%w: color %q: %w", ErrInvalidValue, s, err)
+	}
+
+	switch {
+	case !alpha:
+		return RGB(uint8(n>>16), uint8(n>>8), uint8(n)), nil
+	case argb:
+		return RGBA(uint8(n>>16), uint8(n>>8), uint8(n), uint8(n>>24)), nil
+	default:
+		return RGBA(uint8(n>>24), uint8(n>>16), uint8(n>>8), uint8(n)), nil
+	}
+}
+
+// String returns the color in the format 'rgba(rrggbbaa)'.
+func (c Color) String() string {
+	return fmt.Sprintf("rgba(%02x%02x%02x%02x)", c.R, c.G, c.B, c.A)
+}
+
+// Gradient is a list of colors with an angle in degrees, used e.g.: in
+// border colors. A gradient with a single color is a solid color.
+type Gradient struct {
+	Colors []Color
+	Angle  int
+}
+
+// ParseGradient parses a gradient in the format '<color> [<color>...]
+// [<angle>deg]', e.g.: 'rgba(33ccffee) rgba(00ff99ee) 45deg'.
+func ParseGradient(s string) (g Gradient, err error) {
+	for _, field := range strings.Fields(s) {
+		if angle, ok := strings.CutSuffix(field, "deg"); ok {
+			g.Angle, err = strconv.Atoi(angle)
+			if err != nil {
+				return g, fmt.Errorf("%w: gradient angle %q: %w", ErrInvalidValue, field, err)
+			}
+
+			continue
+		}
+
+		c, err := ParseColor(field)
+		if err != nil {
+			return g, err
+		}
+
+		g.Colors = append(g.Colors, c)
+	}
+
+	if len(g.Colors) == 0 {
+		return g, fmt.Errorf("%w: gradient %q without colors", ErrInvalidValue, s)
+	}
+
+	return g, nil
+}
+
+// String returns the gradient in the format expected by Hyprland, e.g.:
+// 'rgba(33ccffee) rgba(00ff99ee) 45deg'.
+func (g Gradient) String() string {
+	fields := make([]string, 0, len(g.Colors)+1)
+	for _, c := range g.Colors {
+		fields = append(fields, c.String())
+	}
+
+	return strings.Join(append(fields, strconv.Itoa(g.Angle)+"deg"), " ")
+}
+
+// Vec2 is a 2D vector, e.g.: a size or an offset.
+type Vec2 struct {
+	X, Y float64
+}
+
+// String returns the vector in the format expected by Hyprland, e.g.: '10 20'.
+func (v Vec2) String() string {
+	return formatFloat(v.X) + " " + formatFloat(v.Y)
+}
@@ -0,0 +1,62 @@
+package hyprland
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagokokada/hyprland-go/internal/assert"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  Color
+	}{
+		{"rgba(33ccffee)", RGBA(0x33, 0xcc, 0xff, 0xee)},
+		{"rgb(33ccff)", RGB(0x33, 0xcc, 0xff)},
+		{"0xee33ccff", RGBA(0x33, 0xcc, 0xff, 0xee)},
+		{"ee33ccff", RGBA(0x33, 0xcc, 0xff, 0xee)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			got, err := ParseColor(tt.color)
+			assert.NoError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+
+	for _, color := range []string{"", "rgba(33ccff)", "rgb(33ccffee)", "0xffffff", "rgba(gggggggg)"} {
+		_, err := ParseColor(color)
+		assert.True(t, errors.Is(err, ErrInvalidValue))
+	}
+}
+
+func TestColorString(t *testing.T) {
+	assert.Equal(t, RGBA(0x33, 0xcc, 0xff, 0xee).String(), "rgba(33ccffee)")
+	assert.Equal(t, RGB(0, 0, 0).String(), "rgba(000000ff)")
+}
+
+func TestParseGradient(t *testing.T) {
+	g, err := ParseGradient("rgba(33ccffee) rgba(00ff99ee) 45deg")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, g, Gradient{
+		Colors: []Color{RGBA(0x33, 0xcc, 0xff, 0xee), RGBA(0x00, 0xff, 0x99, 0xee)},
+		Angle:  45,
+	})
+	assert.Equal(t, g.String(), "rgba(33ccffee) rgba(00ff99ee) 45deg")
+
+	// Format returned by getprop
+	g, err = ParseGradient("ee33ccff 0deg")
+	assert.NoError(t, err)
+	assert.DeepEqual(t, g, Gradient{Colors: []Color{RGBA(0x33, 0xcc, 0xff, 0xee)}})
+
+	for _, gradient := range []string{"", "45deg", "rgba(33ccffee) fooddeg"} {
+		_, err := ParseGradient(gradient)
+		assert.True(t, errors.Is(err, ErrInvalidValue))
+	}
+}
+
+func TestVec2String(t *testing.T) {
+	assert.Equal(t, Vec2{800, 600}.String(), "800 600")
+	assert.Equal(t, Vec2{-1.5, 0}.String(), "-1.5 0")
+}